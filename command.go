@@ -1,11 +1,13 @@
 package rain
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/spf13/cobra"
 
+	"github.com/yrbb/rain/pkg/lock"
 	"github.com/yrbb/rain/pkg/logger"
 )
 
@@ -15,7 +17,8 @@ var RootCmd = &cobra.Command{
 }
 
 func init() {
-	RootCmd.PersistentFlags().StringP("config", "c", "", "config file")
+	RootCmd.PersistentFlags().StringP("config", "c", "", "config file, toml, yaml or json by extension")
+	RootCmd.PersistentFlags().String("profile", "", "config profile, overlays config.<profile>.<ext>, default $RAIN_PROFILE")
 	// RootCmd.MarkPersistentFlagRequired("config")
 	// RootCmd.MarkFlagFilename("config", "toml")
 }
@@ -44,6 +47,17 @@ func wrapCommandRunE(p *Rain, cmd *cobra.Command) {
 		return func(cmd2 *cobra.Command, args []string) error {
 			p.beforeStartCallback()
 
+			if err := p.lockCommand(cmd2); err != nil {
+				if errors.Is(err, lock.ErrNotObtained) {
+					logger.M().Info(fmt.Sprintf("Command %s 已在其他实例运行", cmd2.Name()))
+				} else {
+					logger.M().Error(fmt.Sprintf("Command %s 加锁异常", cmd2.Name()), slog.String("error", err.Error()))
+				}
+
+				p.stop()
+				return nil
+			}
+
 			if name := cmd2.Name(); name != "version" && name != "server" {
 				logger.M().Info("开始执行 Command: " + name)
 			}
@@ -3,21 +3,31 @@ package rain
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/BurntSushi/toml"
 	"github.com/fsnotify/fsnotify"
 	"github.com/panjf2000/ants/v2"
 
+	"github.com/yrbb/rain/pkg/auth"
 	"github.com/yrbb/rain/pkg/database"
+	"github.com/yrbb/rain/pkg/logger"
+	"github.com/yrbb/rain/pkg/logger/handler"
+	"github.com/yrbb/rain/pkg/logger/writer"
+	"github.com/yrbb/rain/pkg/middleware"
+	"github.com/yrbb/rain/pkg/queue"
 	"github.com/yrbb/rain/pkg/redis"
 	"github.com/yrbb/rain/pkg/utils"
 )
 
 type Config struct {
-	mu sync.Mutex
+	mu   sync.Mutex
+	tree map[string]any // 合并后的原始配置, 用于按路径读取
 
 	Debug    bool              `toml:"debug"`
 	Project  string            `toml:"project"`
@@ -26,6 +36,9 @@ type Config struct {
 	Worker   workerConfig      `toml:"worker"`
 	Database []database.Config `toml:"database"`
 	Redis    []redis.Config    `toml:"redis"`
+	Auth     auth.Config       `toml:"auth"`
+	Queue    queueConfig       `toml:"queue"`
+	Schedule scheduleConfig    `toml:"schedule"`
 	Custom   map[string]any    `toml:"custom"`
 }
 
@@ -35,6 +48,14 @@ type serverConfig struct {
 	WriteTimeout time.Duration `toml:"write_timeout"` // second
 	StopTimeout  time.Duration `toml:"stop_timeout"`  // second
 	EnablePProf  bool          `toml:"enable_pprof"`
+	MaxBodySize  int64         `toml:"max_body_size"` // byte, 0 不限制
+
+	AccessLog   accessLogConfig   `toml:"access_log"`
+	CORS        corsConfig        `toml:"cors"`
+	Security    securityConfig    `toml:"security"`
+	Compress    compressConfig    `toml:"compress"`
+	RateLimit   rateLimitConfig   `toml:"rate_limit"`
+	Idempotency idempotencyConfig `toml:"idempotency"`
 }
 
 func (s *serverConfig) validate() error {
@@ -45,22 +66,341 @@ func (s *serverConfig) validate() error {
 
 	s.Listen = fmt.Sprintf("%s:%d", host, port)
 
-	s.ReadTimeout *= time.Second
-	s.WriteTimeout *= time.Second
-
 	// 默认停止超时时间 10s
 	if s.StopTimeout == 0 {
 		s.StopTimeout = 10
 	}
-	s.StopTimeout *= time.Second
+
+	if err = s.CORS.validate(); err != nil {
+		return err
+	}
+
+	s.Idempotency.validate()
+
+	return s.RateLimit.validate()
+}
+
+type accessLogConfig struct {
+	IgnorePaths     []string `toml:"ignore_paths"` // 默认 /health
+	Headers         []string `toml:"headers"`
+	Query           bool     `toml:"query"`
+	UserAgent       bool     `toml:"user_agent"`
+	Route           bool     `toml:"route"`            // 使用路由模板替代原始路径
+	SampleThreshold int      `toml:"sample_threshold"` // 每秒 2xx 日志数超过该值后开始采样
+	SampleRate      int      `toml:"sample_rate"`      // 采样时每 N 条记录 1 条
+	RequestBody     bool     `toml:"request_body"`
+	ResponseBody    bool     `toml:"response_body"`
+	MaxBodySize     int      `toml:"max_body_size"` // byte, 默认 4096
+	RedactFields    []string `toml:"redact_fields"`
+}
+
+func (a *accessLogConfig) options() middleware.LoggerOptions {
+	return middleware.LoggerOptions{
+		IgnorePaths:     a.IgnorePaths,
+		Headers:         a.Headers,
+		Query:           a.Query,
+		UserAgent:       a.UserAgent,
+		Route:           a.Route,
+		SampleThreshold: a.SampleThreshold,
+		SampleRate:      a.SampleRate,
+		RequestBody:     a.RequestBody,
+		ResponseBody:    a.ResponseBody,
+		MaxBodySize:     a.MaxBodySize,
+		RedactFields:    a.RedactFields,
+	}
+}
+
+type corsConfig struct {
+	Enable           bool          `toml:"enable"`
+	AllowOrigins     []string      `toml:"allow_origins"`
+	AllowMethods     []string      `toml:"allow_methods"`
+	AllowHeaders     []string      `toml:"allow_headers"`
+	ExposeHeaders    []string      `toml:"expose_headers"`
+	AllowCredentials bool          `toml:"allow_credentials"`
+	MaxAge           time.Duration `toml:"max_age"` // second
+}
+
+func (c *corsConfig) validate() error {
+	if c.Enable && c.AllowCredentials && slices.Contains(c.AllowOrigins, "*") {
+		return errors.New("cors allow_origins 为 * 时不能开启 allow_credentials")
+	}
+
+	return nil
+}
+
+func (c *corsConfig) options() middleware.CORSOptions {
+	return middleware.CORSOptions{
+		AllowOrigins:     c.AllowOrigins,
+		AllowMethods:     c.AllowMethods,
+		AllowHeaders:     c.AllowHeaders,
+		ExposeHeaders:    c.ExposeHeaders,
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           c.MaxAge * time.Second,
+	}
+}
+
+type securityConfig struct {
+	Enable                bool          `toml:"enable"`
+	HSTSMaxAge            time.Duration `toml:"hsts_max_age"` // second
+	HSTSIncludeSubdomains bool          `toml:"hsts_include_subdomains"`
+	HSTSPreload           bool          `toml:"hsts_preload"`
+	FrameOptions          string        `toml:"frame_options"` // DENY, SAMEORIGIN
+	ContentSecurityPolicy string        `toml:"content_security_policy"`
+	ReferrerPolicy        string        `toml:"referrer_policy"`
+}
+
+func (s *securityConfig) options() middleware.SecureOptions {
+	return middleware.SecureOptions{
+		HSTSMaxAge:            s.HSTSMaxAge * time.Second,
+		HSTSIncludeSubdomains: s.HSTSIncludeSubdomains,
+		HSTSPreload:           s.HSTSPreload,
+		FrameOptions:          s.FrameOptions,
+		ContentSecurityPolicy: s.ContentSecurityPolicy,
+		ReferrerPolicy:        s.ReferrerPolicy,
+	}
+}
+
+type compressConfig struct {
+	Enable        bool     `toml:"enable"`
+	Level         int      `toml:"level"`          // 1-9, 默认 6
+	MinSize       int      `toml:"min_size"`       // byte, 默认 1024
+	ExcludedTypes []string `toml:"excluded_types"` // Content-Type 前缀
+}
+
+func (c *compressConfig) options() middleware.CompressOptions {
+	return middleware.CompressOptions{
+		Level:         c.Level,
+		MinSize:       c.MinSize,
+		ExcludedTypes: c.ExcludedTypes,
+	}
+}
+
+type rateLimitConfig struct {
+	Enable    bool          `toml:"enable"`
+	Algorithm string        `toml:"algorithm"` // token_bucket, sliding_window
+	KeyBy     string        `toml:"key_by"`    // ip, route, ip_route
+	Limit     int           `toml:"limit"`     // 每个窗口允许的请求数
+	Burst     int           `toml:"burst"`     // 令牌桶容量, 默认等于 limit
+	Window    time.Duration `toml:"window"`    // second, 默认 1s
+	Store     string        `toml:"store"`     // local, redis
+	Redis     string        `toml:"redis"`     // store 为 redis 时使用的实例名, 默认 default
+	Prefix    string        `toml:"prefix"`
+}
+
+func (r *rateLimitConfig) validate() error {
+	if !r.Enable {
+		return nil
+	}
+
+	if r.Limit <= 0 {
+		return errors.New("限流配置 limit 必须大于 0")
+	}
+
+	switch r.Algorithm {
+	case "":
+		r.Algorithm = middleware.RateLimitTokenBucket
+	case middleware.RateLimitTokenBucket, middleware.RateLimitSlidingWindow:
+	default:
+		return fmt.Errorf("不支持的限流算法: %s", r.Algorithm)
+	}
+
+	switch r.KeyBy {
+	case "":
+		r.KeyBy = "ip"
+	case "ip", "route", "ip_route":
+	default:
+		return fmt.Errorf("不支持的限流 key_by: %s", r.KeyBy)
+	}
+
+	switch r.Store {
+	case "":
+		r.Store = "local"
+	case "local", "redis":
+	default:
+		return fmt.Errorf("不支持的限流 store: %s", r.Store)
+	}
+
+	if r.Redis == "" {
+		r.Redis = "default"
+	}
+
+	if r.Window == 0 {
+		r.Window = 1
+	}
+
+	return nil
+}
+
+func (r *rateLimitConfig) options() middleware.RateLimitOptions {
+	opts := middleware.RateLimitOptions{
+		Algorithm: r.Algorithm,
+		Limit:     r.Limit,
+		Burst:     r.Burst,
+		Window:    r.Window * time.Second,
+		Prefix:    r.Prefix,
+	}
+
+	switch r.KeyBy {
+	case "route":
+		opts.KeyFunc = middleware.RateLimitKeyByRoute
+	case "ip_route":
+		opts.KeyFunc = middleware.RateLimitKeyByIPRoute
+	default:
+		opts.KeyFunc = middleware.RateLimitKeyByIP
+	}
+
+	return opts
+}
+
+type queueConfig struct {
+	Redis       string        `toml:"redis"`        // 使用的 Redis 实例名, 默认 default
+	Prefix      string        `toml:"prefix"`       // stream key 前缀, 默认 queue:
+	Group       string        `toml:"group"`        // 消费组, 默认为项目名
+	Concurrency int           `toml:"concurrency"`  // 每个队列同时执行的任务数, 默认 10
+	MaxRetries  int           `toml:"max_retries"`  // 默认 3, 小于 0 时不重试
+	Backoff     time.Duration `toml:"backoff"`      // second, 首次重试间隔, 之后指数增长, 默认 1s
+	MaxBackoff  time.Duration `toml:"max_backoff"`  // second, 默认 300s
+	ClaimIdle   time.Duration `toml:"claim_idle"`   // second, 任务超过该时间未确认由其他消费者接管, 默认 300s
+	MaxLen      int64         `toml:"max_len"`      // stream 近似最大长度, 0 表示不限制
+	StopTimeout time.Duration `toml:"stop_timeout"` // second, 停止时等待执行中任务的时间, 默认 30s
+}
+
+func (q *queueConfig) validate(project string) {
+	if q.Redis == "" {
+		q.Redis = "default"
+	}
+
+	if q.Group == "" {
+		q.Group = project
+	}
+
+	if q.Backoff == 0 {
+		q.Backoff = 1
+	}
+
+	if q.MaxBackoff == 0 {
+		q.MaxBackoff = 300
+	}
+
+	if q.StopTimeout == 0 {
+		q.StopTimeout = 30
+	}
+}
+
+func (q *queueConfig) options() queue.Options {
+	return queue.Options{
+		Prefix:      q.Prefix,
+		Group:       q.Group,
+		Concurrency: q.Concurrency,
+		MaxRetries:  q.MaxRetries,
+		Backoff:     queue.ExponentialBackoff(q.Backoff*time.Second, q.MaxBackoff*time.Second),
+		ClaimIdle:   q.ClaimIdle * time.Second,
+		MaxLen:      q.MaxLen,
+		Submit:      Go,
+	}
+}
+
+type scheduleConfig struct {
+	Timezone    string        `toml:"timezone"`     // 默认时区, 如 Asia/Shanghai, 默认为本地时区
+	StopTimeout time.Duration `toml:"stop_timeout"` // second, 停止时等待执行中任务的时间, 默认 30s
+
+	location *time.Location
+}
+
+func (s *scheduleConfig) validate() (err error) {
+	s.location = time.Local
+
+	if s.Timezone != "" {
+		if s.location, err = time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("定时任务时区异常: %w", err)
+		}
+	}
+
+	if s.StopTimeout == 0 {
+		s.StopTimeout = 30
+	}
 
 	return nil
 }
 
+type idempotencyConfig struct {
+	Enable  bool          `toml:"enable"`
+	Redis   string        `toml:"redis"`   // 使用的 Redis 实例名, 默认 default
+	Header  string        `toml:"header"`  // 默认 Idempotency-Key
+	Methods []string      `toml:"methods"` // 默认 POST, PATCH
+	Expire  time.Duration `toml:"expire"`  // second, 响应缓存时间, 默认 86400
+	Prefix  string        `toml:"prefix"`
+}
+
+func (i *idempotencyConfig) validate() {
+	if i.Redis == "" {
+		i.Redis = "default"
+	}
+}
+
+func (i *idempotencyConfig) options() middleware.IdempotencyOptions {
+	return middleware.IdempotencyOptions{
+		Header:  i.Header,
+		Methods: i.Methods,
+		Expire:  i.Expire * time.Second,
+		Prefix:  i.Prefix,
+	}
+}
+
 type logConfig struct {
-	Path      string        `toml:"path"`
-	Level     string        `toml:"level"`
-	SplitTime time.Duration `toml:"split_time"`
+	Path         string        `toml:"path"`
+	Level        string        `toml:"level"`
+	SplitTime    time.Duration `toml:"split_time"`     // minute
+	MaxSize      int64         `toml:"max_size"`       // MB, 单个文件超过后在同一时间段内切分, 0 不限制
+	MaxAge       int           `toml:"max_age"`        // hour, 超过后删除, 0 不删除
+	MaxTotalSize int64         `toml:"max_total_size"` // MB, 日志文件总大小超过后删除最早的文件, 0 不限制
+	Compress     bool          `toml:"compress"`       // gzip 压缩已切分的文件
+	Symlink      bool          `toml:"symlink"`        // 创建指向当前文件的软链接 <project>.log
+
+	BufferSize    int           `toml:"buffer_size"`    // 缓冲的最大日志条数, 默认 8192
+	Overflow      string        `toml:"overflow"`       // 缓冲区满时: block (默认), drop_oldest, drop_newest
+	FsyncInterval time.Duration `toml:"fsync_interval"` // second, 0 不主动 fsync
+	Sync          bool          `toml:"sync"`           // 同步写入, 不使用缓冲
+
+	Sinks  []logSinkConfig   `toml:"sinks"`  // 为空时写入 path 目录下的 json 文件
+	Levels map[string]string `toml:"levels"` // 具名日志的级别, eg: orm = "debug", 未设置时使用 level
+	Redact logRedactConfig   `toml:"redact"`
+}
+
+type logRedactConfig struct {
+	Disable bool     `toml:"disable"`
+	Keys    []string `toml:"keys"`   // 追加的脱敏字段名, 默认 password, passwd, token, secret, card, authorization
+	Values  []string `toml:"values"` // 字符串值中需要脱敏的正则, eg: 1[3-9]\d{9}
+	Mask    string   `toml:"mask"`   // 默认 ******
+
+	values []*regexp.Regexp
+}
+
+func (r *logRedactConfig) validate() error {
+	r.values = r.values[:0]
+
+	for _, v := range r.Values {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			return fmt.Errorf("日志脱敏正则 %s 异常: %w", v, err)
+		}
+
+		r.values = append(r.values, re)
+	}
+
+	return nil
+}
+
+func (r *logRedactConfig) redactor() *handler.Redactor {
+	if r.Disable {
+		return nil
+	}
+
+	return handler.RedactOptions{
+		Keys:   append(append([]string{}, handler.DefaultRedactKeys...), r.Keys...),
+		Values: r.values,
+		Mask:   r.Mask,
+	}.NewRedactor()
 }
 
 func (l *logConfig) validate() error {
@@ -76,13 +416,185 @@ func (l *logConfig) validate() error {
 		l.SplitTime = 60
 	}
 
+	if l.MaxSize < 0 || l.MaxAge < 0 || l.MaxTotalSize < 0 {
+		return errors.New("日志 max_size, max_age, max_total_size 不能小于 0")
+	}
+
+	if err := l.Redact.validate(); err != nil {
+		return err
+	}
+
+	for name, lvl := range l.Levels {
+		if _, ok := logger.ParseLevel(lvl); !ok {
+			return fmt.Errorf("日志 %s 级别 %s 不支持", name, lvl)
+		}
+	}
+
+	switch l.Overflow {
+	case "", writer.OverflowBlock, writer.OverflowDropOldest, writer.OverflowDropNewest:
+	default:
+		return fmt.Errorf("日志 overflow %s 不支持", l.Overflow)
+	}
+
 	if ok, _ := utils.IsWritable(l.Path); !ok {
 		return errors.New("日志目录不存在或者不可写")
 	}
 
+	if len(l.Sinks) == 0 {
+		l.Sinks = []logSinkConfig{{Type: "file"}}
+	}
+
+	for i := range l.Sinks {
+		if err := l.Sinks[i].validate(l.Path); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// sinks 创建日志输出目标, 失败时关闭已创建的 writer
+func (l *logConfig) sinks(project string) ([]logger.Sink, error) {
+	sinks := make([]logger.Sink, 0, len(l.Sinks))
+
+	for _, s := range l.Sinks {
+		w, err := writer.New(s.Type, s.config(project, l.options()))
+		if err != nil {
+			for _, sink := range sinks {
+				sink.Writer.Close()
+			}
+
+			return nil, fmt.Errorf("创建日志 %s 异常: %w", s.Type, err)
+		}
+
+		sinks = append(sinks, logger.Sink{
+			Format:  s.Format,
+			Level:   s.Level,
+			NoColor: s.Type != "stdout" && s.Type != "stderr",
+			Writer:  w,
+			Sample:  s.Sample.options(),
+		})
+	}
+
+	return sinks, nil
+}
+
+func (l *logConfig) options() writer.Options {
+	return writer.Options{
+		SplitTime:    l.SplitTime,
+		MaxSize:      l.MaxSize << 20,
+		MaxAge:       time.Duration(l.MaxAge) * time.Hour,
+		MaxTotalSize: l.MaxTotalSize << 20,
+		Compress:     l.Compress,
+		Symlink:      l.Symlink,
+
+		BufferSize:    l.BufferSize,
+		Overflow:      l.Overflow,
+		FsyncInterval: l.FsyncInterval * time.Second,
+		Sync:          l.Sync,
+	}
+}
+
+type logSinkConfig struct {
+	Type    string         `toml:"type"`    // file, stdout, stderr, syslog, http 及 writer.Register 注册的类型
+	Format  string         `toml:"format"`  // json, text, 默认 stdout, stderr 为 text, 其他为 json
+	Level   string         `toml:"level"`   // 默认使用 logger.level
+	Path    string         `toml:"path"`    // file, 默认 logger.path, 切分及保留策略同 logger
+	Network string         `toml:"network"` // syslog, unixgram, unix
+	Address string         `toml:"address"` // syslog socket 路径, 默认 /dev/log; http 接收日志的 URL
+	Tag     string         `toml:"tag"`     // syslog, 默认 project
+	Options map[string]any `toml:"options"` // 自定义类型的配置
+
+	Headers       map[string]string `toml:"headers"`        // http
+	BatchSize     int               `toml:"batch_size"`     // http, 默认 100
+	FlushInterval time.Duration     `toml:"flush_interval"` // http, millisecond, 默认 1000
+	Timeout       time.Duration     `toml:"timeout"`        // http, second, 默认 5
+	MaxRetries    int               `toml:"max_retries"`    // http, 默认 3
+
+	Sample logSampleConfig `toml:"sample"`
+}
+
+type logSampleConfig struct {
+	Enable        bool          `toml:"enable"`
+	Interval      time.Duration `toml:"interval"`       // second, 默认 1
+	First         int           `toml:"first"`          // 每个周期内相同级别及消息的前 N 条全部输出, 0 不采样
+	Thereafter    int           `toml:"thereafter"`     // 之后每 M 条输出 1 条, 0 全部丢弃
+	Dedup         bool          `toml:"dedup"`          // 相同的日志只输出一条, 周期结束时输出重复次数
+	DedupInterval time.Duration `toml:"dedup_interval"` // second, 默认 10
+}
+
+func (s *logSampleConfig) options() *handler.SampleOptions {
+	if !s.Enable {
+		return nil
+	}
+
+	return &handler.SampleOptions{
+		Interval:      s.Interval * time.Second,
+		First:         s.First,
+		Thereafter:    s.Thereafter,
+		Dedup:         s.Dedup,
+		DedupInterval: s.DedupInterval * time.Second,
+	}
+}
+
+func (s *logSinkConfig) validate(path string) error {
+	if !writer.Registered(s.Type) {
+		return fmt.Errorf("日志 sink 类型 %s 不支持, 可选: %v", s.Type, writer.Types())
+	}
+
+	if s.Format == "" {
+		s.Format = "json"
+		if s.Type == "stdout" || s.Type == "stderr" {
+			s.Format = "text"
+		}
+	}
+
+	if s.Format != "json" && s.Format != "text" {
+		return fmt.Errorf("日志 sink %s 格式 %s 不支持", s.Type, s.Format)
+	}
+
+	if _, ok := logger.ParseLevel(s.Level); s.Level != "" && !ok {
+		return fmt.Errorf("日志 sink %s 级别 %s 不支持", s.Type, s.Level)
+	}
+
+	if s.Path == "" {
+		s.Path = path
+	}
+
+	if s.Type == "file" && s.Path != path {
+		if ok, _ := utils.IsWritable(s.Path); !ok {
+			return fmt.Errorf("日志目录 %s 不存在或者不可写", s.Path)
+		}
+	}
+
+	if s.Type == "http" && s.Address == "" {
+		return errors.New("日志 sink http 的 address 不能为空")
+	}
+
+	if s.Sample.First < 0 || s.Sample.Thereafter < 0 {
+		return fmt.Errorf("日志 sink %s 采样 first, thereafter 不能小于 0", s.Type)
+	}
+
+	return nil
+}
+
+func (s *logSinkConfig) config(project string, opts writer.Options) writer.Config {
+	return writer.Config{
+		Project:       project,
+		Path:          s.Path,
+		Options:       opts,
+		Network:       s.Network,
+		Address:       s.Address,
+		Tag:           s.Tag,
+		Headers:       s.Headers,
+		BatchSize:     s.BatchSize,
+		FlushInterval: s.FlushInterval * time.Millisecond,
+		Timeout:       s.Timeout * time.Second,
+		MaxRetries:    s.MaxRetries,
+		Extra:         s.Options,
+	}
+}
+
 type workerConfig struct {
 	Capacity         int           `toml:"capacity"`
 	ExpireTime       time.Duration `toml:"expire_time"`
@@ -91,6 +603,12 @@ type workerConfig struct {
 	Nonblocking      bool          `toml:"non_blocking"`
 }
 
+func (w *workerConfig) validate() {
+	if w.Capacity == 0 {
+		w.Capacity = 1000
+	}
+}
+
 func (w *workerConfig) Options() []ants.Option {
 	var opts []ants.Option
 
@@ -109,15 +627,15 @@ func (w *workerConfig) Options() []ants.Option {
 	return opts
 }
 
-func parseConfig(file string) (*Config, error) {
-	// file = getConfigPath() + file
-
-	if !utils.FileExists(file) {
-		return nil, fmt.Errorf("配置文件不存在: %s", file)
+// parseConfig 读取基础配置及 profile 对应的覆盖配置, 使用环境变量覆盖后校验
+func parseConfig(file, profile string) (*Config, error) {
+	tree, err := loadConfigTree(file, profile)
+	if err != nil {
+		return nil, err
 	}
 
-	var cfg Config
-	if _, err := toml.DecodeFile(file, &cfg); err != nil {
+	cfg := Config{tree: tree}
+	if err = decodeConfigTree(tree, &cfg); err != nil {
 		return nil, err
 	}
 
@@ -129,54 +647,78 @@ func parseConfig(file string) (*Config, error) {
 		return nil, err
 	}
 
+	cfg.Queue.validate(cfg.Project)
+	cfg.Worker.validate()
+
+	if err := cfg.Schedule.validate(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
-func watchConfig(watcher *fsnotify.Watcher, file string, callback func(*Config)) error {
+// configDebounce 配置文件变化后等待的时间, 合并编辑器保存时的多次事件, 避免读取未写完的文件
+const configDebounce = 500 * time.Millisecond
+
+// watchConfig 监听配置文件所在目录, 基础配置及覆盖配置变化后重新读取合并后的配置, 校验通过后回调.
+// 监听目录以支持编辑器重命名保存及 Kubernetes ConfigMap 的 ..data 软链接替换
+func watchConfig(watcher *fsnotify.Watcher, files []string, load func() (*Config, error), callback func(*Config)) error {
+	names := map[string]bool{}
+	dirs := map[string]bool{}
+
+	for _, file := range files {
+		names[filepath.Clean(file)] = true
+		dirs[filepath.Dir(filepath.Clean(file))] = true
+	}
+
 	go func() {
+		timer := time.NewTimer(configDebounce)
+		timer.Stop()
+
 		for {
 			select {
-			case event := <-watcher.Events:
-				switch event.Op {
-				case fsnotify.Write:
-					fmt.Printf("[CONFIG] Watch %s write ...\n\n", file)
-					var cfg Config
-					if _, err := toml.DecodeFile(file, &cfg); err != nil {
-						continue
-					}
-
-					callback(&cfg)
-				case fsnotify.Rename:
-					fmt.Printf("[CONFIG] Watch %s rename to %s ...\n\n", file, event.Name)
-					if event.Name != file {
-						continue
-					}
-
-					var cfg Config
-					if _, err := toml.DecodeFile(file, &cfg); err != nil {
-						continue
-					}
-
-					callback(&cfg)
-					watcher.Remove(file)
-					watcher.Add(file)
-				}
-			case err, ok := <-watcher.Errors:
+			case event, ok := <-watcher.Events:
 				if !ok {
+					return
+				}
+
+				if !names[filepath.Clean(event.Name)] && !strings.HasPrefix(filepath.Base(event.Name), "..") {
 					continue
 				}
 
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) == 0 {
+					continue
+				}
+
+				timer.Reset(configDebounce)
+			case <-timer.C:
+				cfg, err := load()
 				if err != nil {
-					fmt.Printf("[CONFIG] Watch error: %s\n", err)
+					fmt.Printf("[CONFIG] Reload error: %s\n", err)
+					continue
+				}
+
+				callback(cfg)
+			case err, ok := <-watcher.Errors:
+				if !ok {
 					return
 				}
+
+				fmt.Printf("[CONFIG] Watch error: %s\n", err)
 			}
 		}
 	}()
 
-	return watcher.Add(file)
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
+// Get 返回 custom 中的配置, 数字格式的字符串返回 int64, 需要原始值时使用 GetString
 func (c *Config) Get(name string) any {
 	c.mu.Lock()
 	defer c.mu.Unlock()
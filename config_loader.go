@@ -0,0 +1,499 @@
+package rain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yrbb/rain/pkg/utils"
+)
+
+const (
+	envPrefix  = "RAIN_"        // 覆盖配置项的环境变量前缀, eg: RAIN_SERVER_LISTEN, RAIN_DATABASE_0_ADDR
+	envProfile = "RAIN_PROFILE" // 未指定 --profile 时使用的 profile
+)
+
+// ${VAR} 或 ${VAR:-default}
+var interpolateRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// configFiles 返回基础配置及 profile 对应的覆盖配置, eg: config.toml, config.prod.toml, config.yaml, config.prod.yaml
+func configFiles(file, profile string) []string {
+	files := []string{file}
+
+	if profile != "" {
+		ext := filepath.Ext(file)
+		files = append(files, strings.TrimSuffix(file, ext)+"."+profile+ext)
+	}
+
+	return files
+}
+
+// loadConfigTree 依次读取并合并配置文件, 然后使用环境变量覆盖
+func loadConfigTree(file, profile string) (map[string]any, error) {
+	tree := map[string]any{}
+
+	for _, name := range configFiles(file, profile) {
+		if !utils.FileExists(name) {
+			return nil, fmt.Errorf("配置文件不存在: %s", name)
+		}
+
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		layer, err := decodeConfigFile(name, data)
+		if err != nil {
+			return nil, fmt.Errorf("配置文件 %s 异常: %w", name, err)
+		}
+
+		if err = interpolate(layer); err != nil {
+			return nil, fmt.Errorf("配置文件 %s 异常: %w", name, err)
+		}
+
+		mergeTree(tree, layer)
+	}
+
+	applyEnv(tree, os.Environ())
+
+	return tree, nil
+}
+
+// decodeConfigFile 按扩展名解析配置文件: .yaml, .yml, .json, 其他使用 toml.
+// 不同格式的值统一为 toml 解析后的类型, 合并后按 toml 标签解析到配置结构, 各格式的配置名与 toml 一致
+func decodeConfigFile(name string, data []byte) (map[string]any, error) {
+	layer := map[string]any{}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &layer); err != nil {
+			return nil, err
+		}
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+
+		if err := dec.Decode(&layer); err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := toml.Decode(string(data), &layer); err != nil {
+			return nil, err
+		}
+
+		return layer, nil
+	}
+
+	v, err := normalizeValue(layer)
+	if err != nil {
+		return nil, err
+	}
+
+	if v == nil {
+		return map[string]any{}, nil
+	}
+
+	return v.(map[string]any), nil
+}
+
+// normalizeValue 将 yaml, json 解析的值转换为 toml 对应的类型: 整数为 int64, 小数为 float64, 表数组为 []map[string]any, 忽略 null
+func normalizeValue(v any) (any, error) {
+	switch val := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			item, err := normalizeValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			if item != nil {
+				m[k] = item
+			}
+		}
+
+		return m, nil
+	case map[any]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			item, err := normalizeValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			if item != nil {
+				m[fmt.Sprint(k)] = item
+			}
+		}
+
+		return m, nil
+	case []any:
+		list := make([]any, 0, len(val))
+		for _, item := range val {
+			item, err := normalizeValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			if item != nil {
+				list = append(list, item)
+			}
+		}
+
+		// 与 toml 的表数组一致
+		tables := make([]map[string]any, 0, len(list))
+		for _, item := range list {
+			if m, ok := item.(map[string]any); ok {
+				tables = append(tables, m)
+			}
+		}
+
+		if len(list) > 0 && len(tables) == len(list) {
+			return tables, nil
+		}
+
+		return list, nil
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i, nil
+		}
+
+		return val.Float64()
+	case int:
+		return int64(val), nil
+	case uint64:
+		return int64(val), nil
+	case float32:
+		return float64(val), nil
+	}
+
+	return v, nil
+}
+
+// decodeConfigTree 将合并后的配置解析到 cfg
+func decodeConfigTree(tree map[string]any, cfg *Config) error {
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(tree); err != nil {
+		return err
+	}
+
+	_, err := toml.Decode(buf.String(), cfg)
+
+	return err
+}
+
+// interpolate 替换配置中字符串值内的 ${VAR} 为环境变量的值, 未设置且没有默认值时返回错误.
+// 在解析后的值上替换, 注释及配置名中的 ${VAR} 不处理, 变量值中的引号, 换行等字符不影响配置格式
+func interpolate(tree map[string]any) error {
+	missing := map[string]struct{}{}
+
+	for k, v := range tree {
+		tree[k] = interpolateValue(v, missing)
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return fmt.Errorf("环境变量未设置: %s", strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+func interpolateValue(v any, missing map[string]struct{}) any {
+	switch val := v.(type) {
+	case string:
+		return interpolateRe.ReplaceAllStringFunc(val, func(m string) string {
+			sub := interpolateRe.FindStringSubmatch(m)
+
+			if env, ok := os.LookupEnv(sub[1]); ok {
+				return env
+			}
+
+			if sub[2] != "" {
+				return sub[3]
+			}
+
+			missing[sub[1]] = struct{}{}
+
+			return m
+		})
+	case map[string]any:
+		for k, item := range val {
+			val[k] = interpolateValue(item, missing)
+		}
+	case []map[string]any:
+		for _, item := range val {
+			interpolateValue(item, missing)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = interpolateValue(item, missing)
+		}
+	}
+
+	return v
+}
+
+// mergeTree 将 src 合并到 dst, 表递归合并, 其他值 (包括数组) 整体覆盖
+func mergeTree(dst, src map[string]any) {
+	for k, v := range src {
+		sv, ok1 := v.(map[string]any)
+		dv, ok2 := dst[k].(map[string]any)
+
+		if ok1 && ok2 {
+			mergeTree(dv, sv)
+			continue
+		}
+
+		dst[k] = v
+	}
+}
+
+// applyEnv 使用 RAIN_ 开头的环境变量覆盖配置项, 按配置结构匹配含下划线的配置名,
+// eg: RAIN_SERVER_READ_TIMEOUT => server.read_timeout, RAIN_DATABASE_0_ADDR => database[0].addr
+func applyEnv(tree map[string]any, environ []string) {
+	for _, kv := range environ {
+		k, v, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(k, envPrefix) || k == envProfile {
+			continue
+		}
+
+		tokens := strings.Split(strings.ToLower(strings.TrimPrefix(k, envPrefix)), "_")
+		if err := setEnvValue(tree, reflect.TypeOf(Config{}), tokens, v); err != nil {
+			fmt.Printf("[CONFIG] 忽略环境变量 %s: %s\n", k, err)
+		}
+	}
+}
+
+// setEnvValue 在 node 中设置 tokens 对应的配置项, typ 为 node 对应的结构体或 map 类型
+func setEnvValue(node map[string]any, typ reflect.Type, tokens []string, value string) error {
+	key, fieldType, rest, ok := matchConfigKey(node, typ, tokens)
+	if !ok {
+		return errors.New("配置项不存在")
+	}
+
+	if len(rest) == 0 {
+		v, err := convertEnvValue(value, fieldType, node[key])
+		if err != nil {
+			return err
+		}
+
+		node[key] = v
+
+		return nil
+	}
+
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Struct, reflect.Map:
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			node[key] = child
+		}
+
+		return setEnvValue(child, fieldType, rest, value)
+	case reflect.Interface:
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			return errors.New("配置项不存在")
+		}
+
+		return setEnvValue(child, reflect.TypeOf(map[string]any{}), rest, value)
+	case reflect.Slice:
+		return setEnvIndex(node, key, fieldType, rest, value)
+	}
+
+	return errors.New("配置项不存在")
+}
+
+// setEnvIndex 设置数组中的元素, 下标等于数组长度时追加
+func setEnvIndex(node map[string]any, key string, typ reflect.Type, tokens []string, value string) error {
+	idx, err := strconv.Atoi(tokens[0])
+	if err != nil || idx < 0 {
+		return errors.New("数组下标异常")
+	}
+
+	var list []any
+	switch v := node[key].(type) {
+	case []map[string]any:
+		for _, item := range v {
+			list = append(list, item)
+		}
+	case []any:
+		list = v
+	}
+
+	if idx > len(list) {
+		return errors.New("数组下标越界")
+	}
+
+	elem := typ.Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	if len(tokens) == 1 {
+		v, err := convertEnvValue(value, elem, nil)
+		if err != nil {
+			return err
+		}
+
+		if idx == len(list) {
+			list = append(list, v)
+		} else {
+			list[idx] = v
+		}
+	} else {
+		if elem.Kind() != reflect.Struct && elem.Kind() != reflect.Map {
+			return errors.New("配置项不存在")
+		}
+
+		if idx == len(list) {
+			list = append(list, map[string]any{})
+		}
+
+		child, ok := list[idx].(map[string]any)
+		if !ok {
+			return errors.New("配置项类型异常")
+		}
+
+		if err = setEnvValue(child, elem, tokens[1:], value); err != nil {
+			return err
+		}
+	}
+
+	node[key] = list
+
+	return nil
+}
+
+// matchConfigKey 按最长匹配返回 tokens 开头对应的配置名, 配置项类型及剩余的 tokens
+func matchConfigKey(node map[string]any, typ reflect.Type, tokens []string) (string, reflect.Type, []string, bool) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	names := map[string]string{}       // 小写 => 配置名
+	types := map[string]reflect.Type{} // 配置名 => 类型
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		collectConfigFields(typ, names, types)
+	case reflect.Map:
+		for k := range node {
+			names[strings.ToLower(k)] = k
+			types[k] = typ.Elem()
+		}
+	default:
+		return "", nil, nil, false
+	}
+
+	for i := len(tokens); i > 0; i-- {
+		if name, ok := names[strings.Join(tokens[:i], "_")]; ok {
+			return name, types[name], tokens[i:], true
+		}
+	}
+
+	// map 中不存在时新增
+	if typ.Kind() == reflect.Map {
+		return strings.Join(tokens, "_"), typ.Elem(), nil, true
+	}
+
+	return "", nil, nil, false
+}
+
+func collectConfigFields(typ reflect.Type, names map[string]string, types map[string]reflect.Type) {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			collectConfigFields(f.Type, names, types)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		names[strings.ToLower(name)] = name
+		types[name] = f.Type
+	}
+}
+
+// convertEnvValue 按配置项类型转换环境变量的值, 类型不确定时参考原值, 数组使用逗号分隔
+func convertEnvValue(value string, typ reflect.Type, old any) (any, error) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return value, nil
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(value, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(value, 10, 64)
+		return int64(v), err
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	case reflect.Slice:
+		var list []any
+		for _, item := range strings.Split(value, ",") {
+			v, err := convertEnvValue(strings.TrimSpace(item), typ.Elem(), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, v)
+		}
+
+		return list, nil
+	case reflect.Interface:
+		switch old.(type) {
+		case int64:
+			return strconv.ParseInt(value, 10, 64)
+		case float64:
+			return strconv.ParseFloat(value, 64)
+		case bool:
+			return strconv.ParseBool(value)
+		case []any:
+			return convertEnvValue(value, reflect.TypeOf([]string{}), nil)
+		}
+
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("不支持 %s 类型的配置项", typ)
+}
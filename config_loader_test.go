@@ -0,0 +1,177 @@
+package rain
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseLayeredConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.toml")
+
+	base := `
+project = "demo"
+
+[logger]
+path = "` + dir + `"
+
+# 注释中的 ${NOT_SET} 不替换
+[server]
+listen = "${LISTEN_HOST:-127.0.0.1}:8080"
+read_timeout = 5
+
+[[database]]
+name = "db"
+type = "mysql"
+addr = "root:${DB_PASS}@tcp(127.0.0.1:3306)/demo"
+
+[custom]
+zip = "01234"
+secret = "${PAY_SECRET}"
+`
+
+	overlay := `
+[server]
+write_timeout = 10
+
+[custom.payments]
+currency = "CNY"
+`
+
+	_ = os.WriteFile(file, []byte(base), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "config.prod.toml"), []byte(overlay), 0644)
+
+	if _, err := parseConfig(file, ""); err == nil {
+		t.Fatal("expected error of missing DB_PASS")
+	}
+
+	t.Setenv("DB_PASS", "secret")
+	t.Setenv("PAY_SECRET", "a\"b\\c\nenabled = true")
+	t.Setenv("RAIN_SERVER_READ_TIMEOUT", "3")
+	t.Setenv("RAIN_DATABASE_0_MAX_OPEN_CONNS", "20")
+	t.Setenv("RAIN_DATABASE_1_NAME", "db2")
+	t.Setenv("RAIN_CUSTOM_PAYMENTS_CURRENCY", "USD")
+	t.Setenv("RAIN_QUEUE_UNKNOWN_KEY", "x")
+
+	cfg, err := parseConfig(file, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Server.Listen != "127.0.0.1:8080" || cfg.Server.ReadTimeout != 3 || cfg.Server.WriteTimeout != 10 {
+		t.Fatalf("unexpected server config %+v", cfg.Server)
+	}
+
+	if len(cfg.Database) != 2 || cfg.Database[0].Addr != "root:secret@tcp(127.0.0.1:3306)/demo" ||
+		cfg.Database[0].MaxOpenConns != 20 || cfg.Database[1].Name != "db2" {
+		t.Fatalf("unexpected database config %+v", cfg.Database)
+	}
+
+	payments, _ := cfg.Custom["payments"].(map[string]any)
+	if payments["currency"] != "USD" || cfg.Custom["zip"] != "01234" ||
+		cfg.Custom["secret"] != "a\"b\\c\nenabled = true" || cfg.Custom["enabled"] != nil {
+		t.Fatalf("unexpected custom config %v", cfg.Custom)
+	}
+
+	if _, err = parseConfig(file, "staging"); err == nil {
+		t.Fatal("expected error of missing profile file")
+	}
+}
+
+func TestParseConfigFormats(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"config.toml": `
+project = "demo"
+
+[logger]
+path = "` + dir + `"
+
+[server]
+listen = ":8080"
+read_timeout = 5
+
+[[database]]
+name = "db"
+type = "mysql"
+addr = "root@tcp(127.0.0.1:3306)/demo"
+slowThreshold = 0.5
+
+[[redis]]
+name = "default"
+addrs = ["127.0.0.1:7000", "127.0.0.1:7001"]
+
+[custom.payments]
+retries = 3
+rate = 0.5
+methods = ["card", "wallet"]
+`,
+		"config.yaml": `
+project: demo
+logger:
+  path: "` + dir + `"
+server:
+  listen: ":8080"
+  read_timeout: 5
+database:
+  - name: db
+    type: mysql
+    addr: root@tcp(127.0.0.1:3306)/demo
+    slowThreshold: 0.5
+redis:
+  - name: default
+    addrs: ["127.0.0.1:7000", "127.0.0.1:7001"]
+custom:
+  payments:
+    retries: 3
+    rate: 0.5
+    methods: [card, wallet]
+    note: ~
+`,
+		"config.json": `{
+  "project": "demo",
+  "logger": {"path": "` + dir + `"},
+  "server": {"listen": ":8080", "read_timeout": 5},
+  "database": [{"name": "db", "type": "mysql", "addr": "root@tcp(127.0.0.1:3306)/demo", "slowThreshold": 0.5}],
+  "redis": [{"name": "default", "addrs": ["127.0.0.1:7000", "127.0.0.1:7001"]}],
+  "custom": {"payments": {"retries": 3, "rate": 0.5, "methods": ["card", "wallet"], "note": null}}
+}`,
+		"config.prod.yaml": `
+server:
+  write_timeout: 10
+`,
+	}
+
+	for name, data := range files {
+		_ = os.WriteFile(filepath.Join(dir, name), []byte(data), 0644)
+	}
+
+	want, err := parseConfig(filepath.Join(dir, "config.toml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"config.yaml", "config.json"} {
+		cfg, err := parseConfig(filepath.Join(dir, name), "")
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		if !reflect.DeepEqual(cfg.tree, want.tree) || !reflect.DeepEqual(cfg.Database, want.Database) ||
+			!reflect.DeepEqual(cfg.Redis, want.Redis) || !reflect.DeepEqual(cfg.Server, want.Server) {
+			t.Fatalf("%s: unexpected config %+v", name, cfg.tree)
+		}
+	}
+
+	cfg, err := parseConfig(filepath.Join(dir, "config.yaml"), "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Server.WriteTimeout != 10 || cfg.GetInt("custom.payments.retries") != 3 {
+		t.Fatalf("unexpected yaml overlay %+v", cfg.Server)
+	}
+}
@@ -0,0 +1,212 @@
+package rain
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/yrbb/rain/pkg/database"
+	"github.com/yrbb/rain/pkg/logger"
+	"github.com/yrbb/rain/pkg/redis"
+)
+
+// restartSections 修改后需重启才能生效的配置
+var restartSections = []string{"project", "server", "auth", "queue", "schedule", "worker"}
+
+type configChange struct {
+	section string
+	fn      func(cfg *Config) error
+}
+
+// OnConfigChange 配置热更新时 section 对应的配置有变化则调用 fn, section 为点分隔的路径, eg: redis, custom.payments.
+// 调用时 Rain.Config 仍返回旧配置, 新配置通过参数 cfg 获取, BindConfig 绑定的配置已更新.
+// fn 返回错误时放弃本次更新, 已更新的组件及已调用的回调使用旧配置恢复, 当前配置保持不变
+func (p *Rain) OnConfigChange(section string, fn func(cfg *Config) error) {
+	p.configChanges = append(p.configChanges, configChange{section: section, fn: fn})
+}
+
+// reloadConfig 配置文件变化后调用, cfg 已通过 parseConfig 的校验.
+// 依次更新组件及回调, 任一失败时回滚已应用的部分, 全部成功后原子替换当前配置
+func (p *Rain) reloadConfig(cfg *Config) {
+	p.reloadMu.Lock()
+	defer p.reloadMu.Unlock()
+
+	old := p.Config()
+	if reflect.DeepEqual(old.tree, cfg.tree) {
+		return
+	}
+
+	binds, err := p.checkReload(cfg)
+	if err != nil {
+		logger.M().Error("配置校验失败, 忽略本次更新", slog.String("error", err.Error()))
+		return
+	}
+
+	if err := p.applyConfig(old, cfg, binds); err != nil {
+		logger.M().Error("配置更新失败, 已回滚", slog.String("error", err.Error()))
+		return
+	}
+
+	p.applyLogger(old, cfg)
+
+	for _, section := range restartSections {
+		if configChanged(old, cfg, section) {
+			logger.M().Warn(fmt.Sprintf("配置 %s 已修改, 需重启后生效", section))
+		}
+	}
+
+	p.config.Store(cfg)
+	logger.M().Info("配置已更新")
+
+	if p.onConfigUpdate != nil {
+		p.onConfigUpdate(cfg)
+	}
+}
+
+// checkReload 应用前完整校验新配置, 返回 BindConfig 绑定配置解析后的新实例
+func (p *Rain) checkReload(cfg *Config) ([]any, error) {
+	if p.isServer {
+		if err := cfg.Server.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.unmarshalBinds(cfg)
+}
+
+// applyConfig 更新 Redis, 数据库, 绑定配置及 OnConfigChange 回调, 失败时按相反顺序使用旧配置恢复
+func (p *Rain) applyConfig(old, cfg *Config, binds []any) (err error) {
+	var (
+		undo []func()
+		prev []any
+	)
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		// 先恢复绑定的配置, 使用旧配置重新调用的回调读取到一致的值
+		if prev != nil {
+			p.storeBinds(prev)
+		}
+
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	}()
+
+	if configChanged(old, cfg, "redis") {
+		undo = append(undo, func() { _ = p.applyRedis(old.Redis) })
+
+		if err = p.applyRedis(cfg.Redis); err != nil {
+			return err
+		}
+	}
+
+	if configChanged(old, cfg, "database") {
+		undo = append(undo, func() { _ = p.applyDatabase(old.Database) })
+
+		if err = p.applyDatabase(cfg.Database); err != nil {
+			return err
+		}
+	}
+
+	prev = p.storeBinds(binds)
+
+	for _, c := range p.configChanges {
+		if !configChanged(old, cfg, c.section) {
+			continue
+		}
+
+		if err = c.fn(cfg); err != nil {
+			return fmt.Errorf("配置 %s 更新失败: %w", c.section, err)
+		}
+
+		fn := c.fn
+		undo = append(undo, func() { _ = fn(old) })
+	}
+
+	if p.redis != nil {
+		p.redis.SetDebug(cfg.Debug)
+	}
+
+	if p.database != nil {
+		p.database.SetDebug(cfg.Debug)
+	}
+
+	return nil
+}
+
+func (p *Rain) applyRedis(configs []redis.Config) error {
+	if p.redis == nil {
+		r, err := redis.New(configs)
+		if err != nil {
+			return err
+		}
+
+		p.redis = r
+
+		return nil
+	}
+
+	if !p.redis.UpdateConfig(configs) {
+		return errors.New("Redis 配置更新失败")
+	}
+
+	return nil
+}
+
+func (p *Rain) applyDatabase(configs []database.Config) error {
+	if p.database == nil {
+		d, err := database.New(configs)
+		if err != nil {
+			return err
+		}
+
+		p.database = d
+
+		return nil
+	}
+
+	if !p.database.UpdateConfig(configs) {
+		return errors.New("数据库配置更新失败")
+	}
+
+	return nil
+}
+
+// applyLogger 更新调试模式及日志级别, 输出目标等其他日志配置需重启后生效
+func (p *Rain) applyLogger(old, cfg *Config) {
+	logger.SetDebug(cfg.Debug)
+
+	if !configChanged(old, cfg, "logger") {
+		return
+	}
+
+	logger.SetLevel(cfg.Logger.Level)
+
+	for name := range old.Logger.Levels {
+		if _, ok := cfg.Logger.Levels[name]; !ok {
+			_ = logger.SetNamedLevel(name, "")
+		}
+	}
+
+	for name, lvl := range cfg.Logger.Levels {
+		_ = logger.SetNamedLevel(name, lvl)
+	}
+}
+
+// configChanged section 对应的配置在新旧配置中是否不同
+func configChanged(old, cfg *Config, section string) bool {
+	old.mu.Lock()
+	ov, _ := old.lookup(section)
+	old.mu.Unlock()
+
+	cfg.mu.Lock()
+	nv, _ := cfg.lookup(section)
+	cfg.mu.Unlock()
+
+	return !reflect.DeepEqual(ov, nv)
+}
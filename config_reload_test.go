@@ -0,0 +1,100 @@
+package rain
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/yrbb/rain/pkg/logger"
+	"github.com/yrbb/rain/pkg/logger/writer"
+)
+
+func TestReloadConfigRollback(t *testing.T) {
+	logger.Init("test", "error", logger.Sink{Writer: writer.NewFileWriter("test", os.TempDir(), writer.Options{SplitTime: 60})})
+
+	newConfig := func(rate int64) *Config {
+		cfg := &Config{tree: map[string]any{"custom": map[string]any{"pay": map[string]any{"rate": rate}}}}
+		cfg.Custom = map[string]any{"pay": map[string]any{"rate": rate}, "sms": map[string]any{"rate": rate}}
+
+		return cfg
+	}
+
+	type payConfig struct {
+		Rate int `toml:"rate"`
+	}
+
+	p := &Rain{}
+	p.config.Store(newConfig(1))
+	pay := BindConfig[payConfig](p, "custom.pay")
+
+	if err := p.bindConfig(p.Config()); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []int64
+	failed := true
+
+	p.OnConfigChange("custom.pay", func(cfg *Config) error {
+		// 回调执行前绑定的配置已更新
+		if pay.Load().Rate != cfg.GetInt("custom.pay.rate") {
+			t.Errorf("bind %d not updated before callback", pay.Load().Rate)
+		}
+
+		calls = append(calls, int64(cfg.GetInt("custom.pay.rate")))
+		return nil
+	})
+	p.OnConfigChange("custom.sms", func(cfg *Config) error {
+		if failed {
+			return errors.New("sms")
+		}
+
+		return nil
+	})
+
+	p.reloadConfig(newConfig(2))
+
+	if p.Config().GetInt("custom.pay.rate") != 1 || pay.Load().Rate != 1 || len(calls) != 2 || calls[1] != 1 {
+		t.Fatalf("expected rollback, rate=%d bind=%d calls=%v", p.Config().GetInt("custom.pay.rate"), pay.Load().Rate, calls)
+	}
+
+	failed = false
+	p.reloadConfig(newConfig(2))
+
+	if p.Config().GetInt("custom.pay.rate") != 2 || pay.Load().Rate != 2 || calls[len(calls)-1] != 2 {
+		t.Fatalf("expected update, rate=%d bind=%d calls=%v", p.Config().GetInt("custom.pay.rate"), pay.Load().Rate, calls)
+	}
+}
+
+func TestServerValidateIdempotent(t *testing.T) {
+	s := serverConfig{Listen: "127.0.0.1:8080", ReadTimeout: 3}
+	s.CORS.MaxAge = 60
+	s.RateLimit = rateLimitConfig{Enable: true, Limit: 10}
+
+	// 启动及配置热更新时都会校验
+	for i := 0; i < 2; i++ {
+		if err := s.validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if s.ReadTimeout != 3 || s.StopTimeout != 10 || s.CORS.options().MaxAge != time.Minute ||
+		s.RateLimit.options().Window != time.Second {
+		t.Fatalf("unexpected server config %+v", s)
+	}
+
+	q := queueConfig{StopTimeout: 5}
+	sc := scheduleConfig{}
+
+	for i := 0; i < 2; i++ {
+		q.validate("demo")
+
+		if err := sc.validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if q.StopTimeout != 5 || q.options().ClaimIdle != 0 || sc.StopTimeout != 30 {
+		t.Fatalf("unexpected queue config %+v, schedule config %+v", q, sc)
+	}
+}
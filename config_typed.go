@@ -0,0 +1,445 @@
+package rain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// lookup 按点分隔的路径读取配置, eg: server.listen, custom.payments.currency
+func (c *Config) lookup(path string) (any, bool) {
+	parts := strings.Split(path, ".")
+
+	var node any = c.tree
+	if parts[0] == "custom" {
+		node, parts = c.Custom, parts[1:]
+	}
+
+	for _, part := range parts {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if node, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+
+	return node, node != nil
+}
+
+// Has 返回路径对应的配置是否存在
+func (c *Config) Has(path string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.lookup(path)
+
+	return ok
+}
+
+// GetString 返回路径对应的配置, 数字及布尔值转换为字符串, 不存在时返回空字符串
+func (c *Config) GetString(path string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, _ := c.lookup(path)
+
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// GetInt 返回路径对应的配置, 字符串按十进制解析, 不存在或格式错误时返回 0
+func (c *Config) GetInt(path string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, _ := c.lookup(path)
+
+	switch val := v.(type) {
+	case int64:
+		return int(val)
+	case int:
+		return val
+	case float64:
+		return int(val)
+	case string:
+		n, _ := strconv.Atoi(val)
+		return n
+	}
+
+	return 0
+}
+
+// GetBool 返回路径对应的配置, 不存在或格式错误时返回 false
+func (c *Config) GetBool(path string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, _ := c.lookup(path)
+
+	switch val := v.(type) {
+	case bool:
+		return val
+	case string:
+		b, _ := strconv.ParseBool(val)
+		return b
+	}
+
+	return false
+}
+
+// GetDuration 返回路径对应的配置, 数字按秒计算, 字符串支持 1m30s 格式, 不存在或格式错误时返回 0
+func (c *Config) GetDuration(path string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, _ := c.lookup(path)
+
+	switch val := v.(type) {
+	case int64:
+		return time.Duration(val) * time.Second
+	case int:
+		return time.Duration(val) * time.Second
+	case float64:
+		return time.Duration(val * float64(time.Second))
+	case string:
+		if d, err := time.ParseDuration(val); err == nil {
+			return d
+		}
+
+		if n, err := strconv.ParseFloat(val, 64); err == nil {
+			return time.Duration(n * float64(time.Second))
+		}
+	}
+
+	return 0
+}
+
+// GetStringSlice 返回路径对应的数组, 字符串按逗号分隔, 不存在时返回 nil
+func (c *Config) GetStringSlice(path string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, _ := c.lookup(path)
+
+	switch val := v.(type) {
+	case []string:
+		return slices.Clone(val)
+	case []any:
+		res := make([]string, len(val))
+		for i := range val {
+			res[i] = fmt.Sprint(val[i])
+		}
+
+		return res
+	case string:
+		res := strings.Split(val, ",")
+		for i := range res {
+			res[i] = strings.TrimSpace(res[i])
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+// Unmarshal 将路径对应的配置表解析到结构体指针 v, 零值字段使用 default 标签的值, 然后按 validate 标签校验,
+// 支持 required, min, max, oneof, min/max 对数字比较大小, 对字符串, 数组比较长度, eg:
+//
+//	Currency string        `toml:"currency" default:"CNY" validate:"oneof=CNY USD"`
+//	Timeout  time.Duration `toml:"timeout" default:"5s" validate:"min=1s,max=1m"`
+//	Merchant string        `toml:"merchant" validate:"required"`
+func (c *Config) Unmarshal(path string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("配置解析的目标必须为结构体指针")
+	}
+
+	c.mu.Lock()
+	val, ok := c.lookup(path)
+	c.mu.Unlock()
+
+	if ok {
+		table, ok := val.(map[string]any)
+		if !ok {
+			return fmt.Errorf("配置 %s 不是表", path)
+		}
+
+		buf := &bytes.Buffer{}
+		if err := toml.NewEncoder(buf).Encode(table); err != nil {
+			return fmt.Errorf("配置 %s 解析异常: %w", path, err)
+		}
+
+		if _, err := toml.Decode(buf.String(), v); err != nil {
+			return fmt.Errorf("配置 %s 解析异常: %w", path, err)
+		}
+	}
+
+	return checkConfigStruct(path, rv.Elem())
+}
+
+// checkConfigStruct 填充默认值并校验, 递归处理嵌套的结构体及结构体数组
+func checkConfigStruct(path string, rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		name = path + "." + name
+		fv := rv.Field(i)
+
+		if def, ok := f.Tag.Lookup("default"); ok && fv.IsZero() {
+			if err := setConfigValue(fv, def); err != nil {
+				return fmt.Errorf("配置 %s 默认值 %s 异常: %w", name, def, err)
+			}
+		}
+
+		if rule := f.Tag.Get("validate"); rule != "" {
+			if err := validateConfigValue(name, fv, rule); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Time{}):
+			if err := checkConfigStruct(name, fv); err != nil {
+				return err
+			}
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < fv.Len(); j++ {
+				if err := checkConfigStruct(fmt.Sprintf("%s[%d]", name, j), fv.Index(j)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateConfigValue(name string, fv reflect.Value, rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		key, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch key {
+		case "required":
+			if fv.IsZero() {
+				return fmt.Errorf("配置 %s 不能为空", name)
+			}
+		case "min", "max":
+			n, limit, err := configMeasure(fv, arg)
+			if err != nil {
+				return fmt.Errorf("配置 %s 校验规则 %s 异常: %w", name, rule, err)
+			}
+
+			if key == "min" && n < limit {
+				return fmt.Errorf("配置 %s 不能小于 %s", name, arg)
+			}
+
+			if key == "max" && n > limit {
+				return fmt.Errorf("配置 %s 不能大于 %s", name, arg)
+			}
+		case "oneof":
+			if !slices.Contains(strings.Fields(arg), fmt.Sprint(fv.Interface())) {
+				return fmt.Errorf("配置 %s 必须为 %s 之一, 当前为 %v", name, arg, fv.Interface())
+			}
+		case "":
+		default:
+			return fmt.Errorf("配置 %s 校验规则 %s 不支持", name, rule)
+		}
+	}
+
+	return nil
+}
+
+// configMeasure 返回用于 min/max 比较的值及限制, 数字比较大小, 字符串, 数组及 map 比较长度
+func configMeasure(fv reflect.Value, arg string) (float64, float64, error) {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		limit, err := time.ParseDuration(arg)
+		return float64(fv.Int()), float64(limit), err
+	}
+
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), limit, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint()), limit, nil
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), limit, nil
+	case reflect.String, reflect.Slice, reflect.Map:
+		return float64(fv.Len()), limit, nil
+	}
+
+	return 0, 0, fmt.Errorf("不支持 %s 类型", fv.Type())
+}
+
+// setConfigValue 按字段类型解析默认值, 数组使用逗号分隔
+func setConfigValue(fv reflect.Value, s string) error {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+
+		fv.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetFloat(n)
+	case reflect.Slice:
+		items := strings.Split(s, ",")
+		list := reflect.MakeSlice(fv.Type(), len(items), len(items))
+
+		for i, item := range items {
+			if err := setConfigValue(list.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+
+		fv.Set(list)
+	default:
+		return fmt.Errorf("不支持 %s 类型", fv.Type())
+	}
+
+	return nil
+}
+
+// ConfigValue BindConfig 绑定的配置, 热更新时整体替换
+type ConfigValue[T any] struct {
+	v atomic.Pointer[T]
+}
+
+// Load 返回当前配置的快照, 可在任意 goroutine 中调用, 快照不应修改
+func (c *ConfigValue[T]) Load() *T {
+	return c.v.Load()
+}
+
+type configBind struct {
+	path  string
+	typ   reflect.Type
+	load  func() any
+	store func(v any)
+}
+
+// BindConfig 启动前将 path 对应的配置解析到 T 并校验, 失败时终止启动, 见 Config.Unmarshal.
+// 配置热更新时校验通过后替换为新的快照, OnConfigChange 回调执行前已更新, 更新失败时恢复
+func BindConfig[T any](p *Rain, path string) *ConfigValue[T] {
+	cv := &ConfigValue[T]{}
+
+	p.configBinds = append(p.configBinds, configBind{
+		path:  path,
+		typ:   reflect.TypeOf((*T)(nil)).Elem(),
+		load:  func() any { return cv.Load() },
+		store: func(v any) { cv.v.Store(v.(*T)) },
+	})
+
+	return cv
+}
+
+// unmarshalBinds 将 cfg 解析到绑定配置的新实例, 不修改当前的快照
+func (p *Rain) unmarshalBinds(cfg *Config) ([]any, error) {
+	values := make([]any, 0, len(p.configBinds))
+
+	for _, b := range p.configBinds {
+		v := reflect.New(b.typ).Interface()
+		if err := cfg.Unmarshal(b.path, v); err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+// storeBinds 替换绑定配置的快照, 返回替换前的快照
+func (p *Rain) storeBinds(values []any) []any {
+	old := make([]any, len(p.configBinds))
+
+	for i, b := range p.configBinds {
+		old[i] = b.load()
+		b.store(values[i])
+	}
+
+	return old
+}
+
+func (p *Rain) bindConfig(cfg *Config) error {
+	values, err := p.unmarshalBinds(cfg)
+	if err != nil {
+		return err
+	}
+
+	p.storeBinds(values)
+
+	return nil
+}
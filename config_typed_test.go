@@ -0,0 +1,64 @@
+package rain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigTyped(t *testing.T) {
+	cfg := &Config{tree: map[string]any{"server": map[string]any{"listen": ":80"}}}
+	cfg.Custom = map[string]any{
+		"zip": "01234",
+		"payments": map[string]any{
+			"merchant": "m1",
+			"timeout":  "30s",
+			"retries":  int64(3),
+			"methods":  []any{"card", "wallet"},
+			"channels": []map[string]any{{"name": "alipay", "weight": int64(10)}},
+		},
+	}
+
+	if cfg.GetString("custom.zip") != "01234" || cfg.GetString("server.listen") != ":80" ||
+		cfg.GetInt("custom.payments.retries") != 3 || cfg.GetDuration("custom.payments.timeout") != 30*time.Second ||
+		strings.Join(cfg.GetStringSlice("custom.payments.methods"), ",") != "card,wallet" {
+		t.Fatal("unexpected getter values")
+	}
+
+	type channel struct {
+		Name   string `toml:"name" validate:"required"`
+		Weight int    `toml:"weight" validate:"min=1,max=100"`
+	}
+
+	var payments struct {
+		Merchant string        `toml:"merchant" validate:"required"`
+		Currency string        `toml:"currency" default:"CNY" validate:"oneof=CNY USD"`
+		Timeout  time.Duration `toml:"timeout" validate:"min=1s,max=1m"`
+		Retries  int           `toml:"retries" default:"5"`
+		Methods  []string      `toml:"methods" validate:"min=1"`
+		Channels []channel     `toml:"channels"`
+	}
+
+	if err := cfg.Unmarshal("custom.payments", &payments); err != nil {
+		t.Fatal(err)
+	}
+
+	if payments.Currency != "CNY" || payments.Retries != 3 || payments.Timeout != 30*time.Second ||
+		len(payments.Channels) != 1 || payments.Channels[0].Weight != 10 {
+		t.Fatalf("unexpected payments %+v", payments)
+	}
+
+	cfg.Custom["payments"].(map[string]any)["channels"] = []map[string]any{{"name": "alipay", "weight": int64(0)}}
+	err := cfg.Unmarshal("custom.payments", &payments)
+	if err == nil || !strings.Contains(err.Error(), "custom.payments.channels[0].weight") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var missing struct {
+		Key string `toml:"key" validate:"required"`
+	}
+
+	if err = cfg.Unmarshal("custom.missing", &missing); err == nil {
+		t.Fatal("expected required error")
+	}
+}
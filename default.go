@@ -8,7 +8,9 @@ import (
 	"github.com/panjf2000/ants/v2"
 	"github.com/redis/go-redis/v9"
 
+	"github.com/yrbb/rain/pkg/auth"
 	"github.com/yrbb/rain/pkg/orm"
+	"github.com/yrbb/rain/pkg/queue"
 )
 
 var rainIns *Rain
@@ -58,15 +60,37 @@ func MustOrm(name ...string) *orm.Orm {
 	return s
 }
 
-func Redis(name ...string) (*redis.Client, error) {
+func Redis(name ...string) (redis.UniversalClient, error) {
+	if rainIns.redis == nil {
+		return nil, errors.New("Redis 资源未初始化")
+	}
+
 	return rainIns.redis.Get(name...)
 }
 
-func MustRedis(name ...string) *redis.Client {
+func MustRedis(name ...string) redis.UniversalClient {
 	r, _ := Redis(name...)
 	return r
 }
 
+// Auth 返回配置的认证器, 未配置 auth 时 Require 拒绝所有请求
+func Auth() *auth.Authenticator {
+	return rainIns.auth
+}
+
+func Queue() (*queue.Queue, error) {
+	if rainIns.queue == nil {
+		return nil, errors.New("队列未初始化")
+	}
+
+	return rainIns.queue, nil
+}
+
 func GetConfig(name string) any {
-	return rainIns.config.Get(name)
+	return rainIns.Config().Get(name)
+}
+
+// UnmarshalConfig 将 path 对应的配置解析到结构体指针 v 并校验, 见 Config.Unmarshal
+func UnmarshalConfig(path string, v any) error {
+	return rainIns.Config().Unmarshal(path, v)
 }
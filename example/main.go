@@ -22,6 +22,26 @@ func main() {
 		// fmt.Println("on stop...")
 	})
 
+	// type paymentsConfig struct {
+	// 	Merchant string `toml:"merchant" validate:"required"`
+	// 	Currency string `toml:"currency" default:"CNY" validate:"oneof=CNY USD"`
+	// }
+	// payments := rain.BindConfig[paymentsConfig](app, "custom.payments")
+	// payments.Load().Currency
+
+	// app.HandleJob("email", func(ctx context.Context, job *queue.Job) error {
+	// 	return nil
+	// })
+
+	// app.Schedule("*/5 * * * *", "report", func(ctx context.Context) error {
+	// 	return nil
+	// }, schedule.Overlap(schedule.OverlapSkip), schedule.SingleInstance())
+
+	// 配置热更新时 custom.payments 有变化才调用, 返回错误则回滚本次更新
+	// app.OnConfigChange("custom.payments", func(config *rain.Config) error {
+	// 	return nil
+	// })
+
 	app.OnConfigUpdate(func(config *rain.Config) {
 		// if reflect.DeepEqual(config.Custom, app.Config.Custom) {
 		// 	return
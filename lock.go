@@ -0,0 +1,69 @@
+package rain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yrbb/rain/pkg/lock"
+	"github.com/yrbb/rain/pkg/logger"
+)
+
+const annotationSingleInstance = "rain.single_instance"
+
+// SingleInstance 标记命令只在一个实例上运行, 执行前使用 redisName 对应的 Redis 加锁 (默认 default),
+// 未获取到锁的实例直接退出, 租约丢失时 cmd.Context() 会被取消
+func SingleInstance(cmd *cobra.Command, redisName ...string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+
+	name := "default"
+	if len(redisName) > 0 {
+		name = redisName[0]
+	}
+
+	cmd.Annotations[annotationSingleInstance] = name
+}
+
+func (p *Rain) lockCommand(cmd *cobra.Command) error {
+	name, ok := cmd.Annotations[annotationSingleInstance]
+	if !ok {
+		return nil
+	}
+
+	client, err := Redis(name)
+	if err != nil {
+		return err
+	}
+
+	key := p.Config().Project + ":" + strings.ReplaceAll(cmd.CommandPath(), " ", ":")
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	l, err := lock.New(client, lock.Options{Prefix: "rain:lock:"}).TryLock(parent, key)
+	if err != nil {
+		return err
+	}
+
+	logger.M().Info(fmt.Sprintf("获取 Command 锁: %s", l.Key()))
+
+	// 保留父 context 的取消及值, 租约丢失时同样取消
+	ctx, cancel := context.WithCancel(parent)
+	context.AfterFunc(l.Context(), cancel)
+	cmd.SetContext(ctx)
+
+	p.OnStop(func() {
+		if err := l.Release(context.Background()); err != nil {
+			logger.M().Error("释放 Command 锁异常", slog.String("key", l.Key()), slog.String("error", err.Error()))
+		}
+	})
+
+	return nil
+}
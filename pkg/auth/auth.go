@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yrbb/rain/pkg/middleware"
+)
+
+var (
+	ErrNoCredentials = errors.New("no credentials")
+	ErrInvalidAPIKey = errors.New("api key invalid")
+)
+
+type Authenticator struct {
+	config *Config
+	keys   []*key
+}
+
+// New 根据配置创建 Authenticator, 未配置密钥及 API Key 时同样返回可用的实例,
+// 此时任何凭证都无法通过校验, Require 拒绝所有请求
+func New(config *Config) (*Authenticator, error) {
+	// 复制一份配置, 设置默认值时不修改调用方的配置
+	c := *config
+	c.validate()
+
+	a := &Authenticator{config: &c}
+
+	if c.Secret != "" {
+		a.keys = append(a.keys, &key{alg: algHS256, secret: []byte(c.Secret)})
+	}
+
+	if c.PublicKey != "" {
+		pub, err := loadPublicKey(c.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("加载公钥 %s 异常: %v", c.PublicKey, err)
+		}
+
+		a.keys = append(a.keys, &key{alg: algRS256, public: pub})
+	}
+
+	if c.JWKSFile != "" {
+		keys, err := loadJWKS(c.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载 JWKS %s 异常: %v", c.JWKSFile, err)
+		}
+
+		a.keys = append(a.keys, keys...)
+	}
+
+	return a, nil
+}
+
+// Authenticate 依次尝试 Bearer Token 和 API Key, 都未携带时返回 ErrNoCredentials
+func (a *Authenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return a.verifyJWT(strings.TrimSpace(token))
+	}
+
+	if apiKey := r.Header.Get(a.config.APIKeyHeader); apiKey != "" {
+		return a.verifyAPIKey(apiKey)
+	}
+
+	return nil, ErrNoCredentials
+}
+
+func (a *Authenticator) verifyAPIKey(apiKey string) (*Principal, error) {
+	for _, v := range a.config.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(v.Key), []byte(apiKey)) == 1 {
+			return &Principal{
+				Type:    TypeAPIKey,
+				Subject: v.Name,
+				Roles:   v.Roles,
+				Scopes:  v.Scopes,
+			}, nil
+		}
+	}
+
+	return nil, ErrInvalidAPIKey
+}
+
+// Middleware 携带凭证时进行校验并写入 Principal, 未携带凭证的请求直接放行
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetPrincipal(c); ok {
+			return
+		}
+
+		p, err := a.Authenticate(c.Request)
+		if errors.Is(err, ErrNoCredentials) {
+			return
+		}
+
+		if err != nil {
+			a.unauthorized(c, err)
+			return
+		}
+
+		SetPrincipal(c, p)
+	}
+}
+
+// Require 要求请求已认证, 指定 roles 时需至少拥有其中一个角色
+func (a *Authenticator) Require(roles ...string) gin.HandlerFunc {
+	return a.require(func(p *Principal) bool {
+		return len(roles) == 0 || p.HasRole(roles...)
+	})
+}
+
+// RequireScopes 要求请求已认证且拥有全部 scopes
+func (a *Authenticator) RequireScopes(scopes ...string) gin.HandlerFunc {
+	return a.require(func(p *Principal) bool {
+		return p.HasScopes(scopes...)
+	})
+}
+
+func (a *Authenticator) require(allow func(p *Principal) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := GetPrincipal(c)
+		if !ok {
+			var err error
+			if p, err = a.Authenticate(c.Request); err != nil {
+				a.unauthorized(c, err)
+				return
+			}
+
+			SetPrincipal(c, p)
+		}
+
+		if !allow(p) {
+			slog.Warn(
+				"auth-forbidden",
+				slog.String("type", p.Type),
+				slog.String("subject", p.Subject),
+				slog.String("uri", c.Request.URL.Path),
+			)
+
+			middleware.AbortWithStatus(c, http.StatusForbidden, "forbidden")
+		}
+	}
+}
+
+func (a *Authenticator) unauthorized(c *gin.Context, err error) {
+	slog.Warn(
+		"auth-failed",
+		slog.String("error", err.Error()),
+		slog.String("clientIP", c.ClientIP()),
+		slog.String("request", middleware.DumpRequest(c.Request, a.config.APIKeyHeader)),
+	)
+
+	c.Header("WWW-Authenticate", "Bearer")
+	middleware.AbortWithStatus(c, http.StatusUnauthorized, "unauthorized")
+}
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func signToken(t *testing.T, header, claims map[string]any, sign func([]byte) []byte) string {
+	h, _ := json.Marshal(header)
+	c, _ := json.Marshal(claims)
+
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+func hs256(secret string) func([]byte) []byte {
+	return func(data []byte) []byte {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(data)
+		return mac.Sum(nil)
+	}
+}
+
+func TestHS256(t *testing.T) {
+	a, err := New(&Config{Secret: "secret", Issuer: "rain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := map[string]any{"alg": "HS256", "typ": "JWT"}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	cases := []struct {
+		token string
+		err   error
+	}{
+		{signToken(t, header, map[string]any{"sub": "u1", "iss": "rain", "exp": exp}, hs256("secret")), nil},
+		{signToken(t, header, map[string]any{"sub": "u1", "iss": "rain", "exp": exp}, hs256("other")), ErrTokenSignature},
+		{signToken(t, header, map[string]any{"sub": "u1", "iss": "rain", "exp": 1}, hs256("secret")), ErrTokenExpired},
+		{signToken(t, header, map[string]any{"sub": "u1", "iss": "other"}, hs256("secret")), ErrTokenIssuer},
+		{signToken(t, map[string]any{"alg": "none"}, map[string]any{"sub": "u1"}, hs256("secret")), ErrTokenUnsupported},
+		{"a.b", ErrTokenMalformed},
+	}
+
+	for i, v := range cases {
+		p, err := a.verifyJWT(v.token)
+		if err != v.err {
+			t.Fatalf("case %d: expected %v, got %v", i, v.err, err)
+		}
+
+		if err == nil && p.Subject != "u1" {
+			t.Fatalf("case %d: unexpected subject %q", i, p.Subject)
+		}
+	}
+
+	// Leeway 单位为秒, 创建时不修改传入的配置
+	c := &Config{Secret: "secret", Leeway: 60}
+	if a, err = New(c); err != nil {
+		t.Fatal(err)
+	}
+
+	token := signToken(t, header, map[string]any{"sub": "u1", "exp": time.Now().Add(-30 * time.Second).Unix()}, hs256("secret"))
+	if _, err = a.verifyJWT(token); err != nil || c.Leeway != 60 {
+		t.Fatalf("unexpected %v, leeway %d", err, c.Leeway)
+	}
+}
+
+func TestRS256JWKS(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks, _ := json.Marshal(map[string]any{"keys": []map[string]any{{
+		"kty": "RSA",
+		"kid": "k1",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(pk.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pk.E)).Bytes()),
+	}}})
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err = os.WriteFile(file, jwks, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := New(&Config{JWKSFile: file, Audience: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := signToken(t,
+		map[string]any{"alg": "RS256", "kid": "k1"},
+		map[string]any{"sub": "u2", "aud": []string{"api"}, "scope": "read write", "roles": []string{"admin"}},
+		func(data []byte) []byte {
+			sum := sha256.Sum256(data)
+			sig, _ := rsa.SignPKCS1v15(rand.Reader, pk, crypto.SHA256, sum[:])
+			return sig
+		},
+	)
+
+	p, err := a.verifyJWT(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !p.HasScopes("read", "write") || !p.HasRole("admin") {
+		t.Fatalf("unexpected principal %+v", p)
+	}
+}
+
+func TestRequire(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	a, _ := New(&Config{APIKeys: []APIKey{
+		{Name: "admin", Key: "k-admin", Roles: []string{"admin"}},
+		{Name: "user", Key: "k-user"},
+	}})
+
+	r := gin.New()
+	r.Use(a.Middleware())
+	r.GET("/public", func(c *gin.Context) { c.String(200, "ok") })
+	r.Group("/admin", a.Require("admin")).GET("", func(c *gin.Context) { c.String(200, "ok") })
+
+	cases := []struct {
+		path, key string
+		code      int
+	}{
+		{"/public", "", http.StatusOK},
+		{"/public", "bad", http.StatusUnauthorized},
+		{"/admin", "", http.StatusUnauthorized},
+		{"/admin", "k-user", http.StatusForbidden},
+		{"/admin", "k-admin", http.StatusOK},
+	}
+
+	for _, v := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", v.path, nil)
+		if v.key != "" {
+			req.Header.Set("X-API-Key", v.key)
+		}
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != v.code {
+			t.Fatalf("%s %q: expected %d, got %d", v.path, v.key, v.code, w.Code)
+		}
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	a, err := New(&Config{})
+	if err != nil || a == nil {
+		t.Fatalf("expected authenticator, got %v", err)
+	}
+
+	r := gin.New()
+	r.Use(a.Middleware())
+	r.GET("/public", func(c *gin.Context) { c.String(200, "ok") })
+	r.GET("/private", a.Require(), func(c *gin.Context) { c.String(200, "ok") })
+
+	cases := []struct {
+		path, token string
+		code        int
+	}{
+		{"/public", "", http.StatusOK},
+		{"/private", "", http.StatusUnauthorized},
+		{"/private", "Bearer a.b.c", http.StatusUnauthorized},
+	}
+
+	for _, v := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", v.path, nil)
+		if v.token != "" {
+			req.Header.Set("Authorization", v.token)
+		}
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != v.code {
+			t.Fatalf("%s %q: expected %d, got %d", v.path, v.token, v.code, w.Code)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package auth
+
+import "time"
+
+type Config struct {
+	Secret       string        `toml:"secret"`         // HS256 密钥
+	PublicKey    string        `toml:"public_key"`     // RS256 公钥文件 (PEM)
+	JWKSFile     string        `toml:"jwks_file"`      // 本地 JWKS 文件
+	Issuer       string        `toml:"issuer"`         // 为空时不校验
+	Audience     string        `toml:"audience"`       // 为空时不校验
+	Leeway       time.Duration `toml:"leeway"`         // second, exp/nbf 容忍的时钟偏差
+	APIKeyHeader string        `toml:"api_key_header"` // 默认 X-API-Key
+	APIKeys      []APIKey      `toml:"api_keys"`
+}
+
+type APIKey struct {
+	Name   string   `toml:"name"`
+	Key    string   `toml:"key"`
+	Roles  []string `toml:"roles"`
+	Scopes []string `toml:"scopes"`
+}
+
+func (c *Config) Enabled() bool {
+	return c.Secret != "" || c.PublicKey != "" || c.JWKSFile != "" || len(c.APIKeys) > 0
+}
+
+func (c *Config) validate() {
+	if c.APIKeyHeader == "" {
+		c.APIKeyHeader = "X-API-Key"
+	}
+
+	if c.Leeway < 0 {
+		c.Leeway = 0
+	}
+}
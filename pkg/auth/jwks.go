@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+type key struct {
+	kid    string
+	alg    string // HS256, RS256
+	secret []byte
+	public *rsa.PublicKey
+}
+
+func loadJWKS(file string) ([]*key, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("解析 JWKS 文件异常: %v", err)
+	}
+
+	keys := make([]*key, 0, len(set.Keys))
+	for _, v := range set.Keys {
+		if v.Use != "" && v.Use != "sig" {
+			continue
+		}
+
+		switch v.Kty {
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(v.K)
+			if err != nil {
+				return nil, fmt.Errorf("JWKS 密钥 %s 异常: %v", v.Kid, err)
+			}
+
+			keys = append(keys, &key{kid: v.Kid, alg: algHS256, secret: secret})
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(v.N)
+			if err != nil {
+				return nil, fmt.Errorf("JWKS 公钥 %s 异常: %v", v.Kid, err)
+			}
+
+			e, err := base64.RawURLEncoding.DecodeString(v.E)
+			if err != nil {
+				return nil, fmt.Errorf("JWKS 公钥 %s 异常: %v", v.Kid, err)
+			}
+
+			keys = append(keys, &key{
+				kid: v.Kid,
+				alg: algRS256,
+				public: &rsa.PublicKey{
+					N: new(big.Int).SetBytes(n),
+					E: int(new(big.Int).SetBytes(e).Int64()),
+				},
+			})
+		}
+	}
+
+	return keys, nil
+}
+
+func loadPublicKey(file string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("公钥文件不是有效的 PEM 格式")
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if pub, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+			return pub, nil
+		}
+	default:
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if pub, ok := pub.(*rsa.PublicKey); ok {
+			return pub, nil
+		}
+	}
+
+	return nil, errors.New("公钥不是 RSA 类型")
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+)
+
+var (
+	ErrTokenMalformed   = errors.New("token malformed")
+	ErrTokenUnsupported = errors.New("token algorithm unsupported")
+	ErrTokenSignature   = errors.New("token signature invalid")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrTokenNotValidYet = errors.New("token not valid yet")
+	ErrTokenIssuer      = errors.New("token issuer invalid")
+	ErrTokenAudience    = errors.New("token audience invalid")
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (a *Authenticator) verifyJWT(token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	if header.Alg != algHS256 && header.Alg != algRS256 {
+		return nil, ErrTokenUnsupported
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	if !a.verifySignature(&header, signed, signature) {
+		return nil, ErrTokenSignature
+	}
+
+	claims := map[string]any{}
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	if err = a.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	p := &Principal{Type: TypeJWT, Claims: claims}
+	p.Subject, _ = claims["sub"].(string)
+	p.Roles = stringsClaim(claims["roles"])
+
+	// RFC 8693: scope 为空格分隔的字符串, 部分实现使用 scp 数组
+	if scope, ok := claims["scope"].(string); ok {
+		p.Scopes = strings.Fields(scope)
+	} else {
+		p.Scopes = stringsClaim(claims["scp"])
+	}
+
+	return p, nil
+}
+
+func (a *Authenticator) verifySignature(header *jwtHeader, signed, signature []byte) bool {
+	for _, k := range a.keys {
+		if k.alg != header.Alg || (header.Kid != "" && k.kid != "" && k.kid != header.Kid) {
+			continue
+		}
+
+		switch k.alg {
+		case algHS256:
+			mac := hmac.New(sha256.New, k.secret)
+			mac.Write(signed)
+			if hmac.Equal(signature, mac.Sum(nil)) {
+				return true
+			}
+		case algRS256:
+			sum := sha256.Sum256(signed)
+			if rsa.VerifyPKCS1v15(k.public, crypto.SHA256, sum[:], signature) == nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (a *Authenticator) validateClaims(claims map[string]any) error {
+	now := time.Now()
+	leeway := a.config.Leeway * time.Second
+
+	if v, ok := claims["exp"]; ok {
+		exp, ok := numericClaim(v)
+		if !ok {
+			return ErrTokenMalformed
+		}
+
+		if now.After(exp.Add(leeway)) {
+			return ErrTokenExpired
+		}
+	}
+
+	if v, ok := claims["nbf"]; ok {
+		nbf, ok := numericClaim(v)
+		if !ok {
+			return ErrTokenMalformed
+		}
+
+		if now.Add(leeway).Before(nbf) {
+			return ErrTokenNotValidYet
+		}
+	}
+
+	if a.config.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != a.config.Issuer {
+			return ErrTokenIssuer
+		}
+	}
+
+	if a.config.Audience != "" {
+		aud := stringsClaim(claims["aud"])
+		if s, ok := claims["aud"].(string); ok {
+			aud = []string{s}
+		}
+
+		if !slices.Contains(aud, a.config.Audience) {
+			return ErrTokenAudience
+		}
+	}
+
+	return nil
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(v)
+}
+
+func numericClaim(v any) (time.Time, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(f), 0), true
+}
+
+func stringsClaim(v any) []string {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+
+	res := make([]string, 0, len(arr))
+	for _, item := range arr {
+		if s, ok := item.(string); ok {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	TypeJWT    = "jwt"
+	TypeAPIKey = "api_key"
+
+	principalKey = "rain.auth.principal"
+)
+
+type Principal struct {
+	Type    string // jwt, api_key
+	Subject string // JWT sub 或 API Key 名称
+	Roles   []string
+	Scopes  []string
+	Claims  map[string]any // 仅 JWT
+}
+
+func (p *Principal) HasRole(roles ...string) bool {
+	for _, v := range roles {
+		if slices.Contains(p.Roles, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Principal) HasScopes(scopes ...string) bool {
+	for _, v := range scopes {
+		if !slices.Contains(p.Scopes, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func SetPrincipal(c *gin.Context, p *Principal) {
+	c.Set(principalKey, p)
+}
+
+func GetPrincipal(c *gin.Context) (*Principal, bool) {
+	v, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+
+	p, ok := v.(*Principal)
+
+	return p, ok
+}
@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	mrand "math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrNotFound 缓存不存在, 或 loader 返回 ErrNotFound 后写入的负缓存
+	ErrNotFound = errors.New("cache: not found")
+)
+
+const (
+	flagValue    byte = 1
+	flagNotFound byte = 0
+)
+
+type Options struct {
+	Prefix      string        // key 前缀
+	Codec       Codec         // 默认 JSON
+	Jitter      float64       // TTL 随机增加的比例 (0-1), 避免大量 key 同时过期
+	NotFoundTTL time.Duration // loader 返回 ErrNotFound 时的负缓存时间, 0 表示不缓存
+	LocalSize   int           // 进程内 L1 缓存容量, 0 表示不启用
+	LocalTTL    time.Duration // L1 缓存最长时间, 默认 1 分钟
+	Channel     string        // L1 失效通知频道, 默认 Prefix + "cache:invalidate"
+}
+
+type Cache struct {
+	client redis.UniversalClient
+	opts   Options
+	id     string
+	group  group
+	local  *lru
+	pubsub *redis.PubSub
+	wg     sync.WaitGroup
+}
+
+func New(client redis.UniversalClient, opts Options) *Cache {
+	if opts.Codec == nil {
+		opts.Codec = JSON
+	}
+
+	if opts.Jitter < 0 {
+		opts.Jitter = 0
+	}
+
+	if opts.LocalTTL <= 0 {
+		opts.LocalTTL = time.Minute
+	}
+
+	if opts.Channel == "" {
+		opts.Channel = opts.Prefix + "cache:invalidate"
+	}
+
+	c := &Cache{
+		client: client,
+		opts:   opts,
+		id:     newInstanceID(),
+	}
+
+	if opts.LocalSize > 0 {
+		c.local = newLRU(opts.LocalSize, opts.LocalTTL)
+		c.subscribe()
+	}
+
+	return c
+}
+
+// Get 读取缓存并解码到 v, 缓存不存在或为负缓存时返回 ErrNotFound
+func (c *Cache) Get(ctx context.Context, key string, v any) error {
+	data, err := c.get(ctx, key)
+	if errors.Is(err, redis.Nil) {
+		return ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return c.decode(data, v)
+}
+
+func (c *Cache) Set(ctx context.Context, key string, v any, ttl time.Duration) error {
+	data, err := c.opts.Codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return c.set(ctx, key, append([]byte{flagValue}, data...), ttl)
+}
+
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	fullKeys := make([]string, len(keys))
+	for i, v := range keys {
+		fullKeys[i] = c.opts.Prefix + v
+	}
+
+	// cluster 模式下多个 key 可能不在同一个 slot, 逐个删除
+	for _, v := range fullKeys {
+		if err := c.client.Del(ctx, v).Err(); err != nil {
+			return err
+		}
+	}
+
+	c.invalidate(ctx, keys...)
+
+	return nil
+}
+
+// Remember 读取缓存, 不存在时调用 loader 加载并写入缓存, 相同 key 的并发加载只会执行一次 loader
+func Remember[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, loader func(ctx context.Context) (T, error)) (T, error) {
+	var res T
+
+	data, err := c.get(ctx, key)
+	if err == nil {
+		return res, c.decode(data, &res)
+	}
+
+	// Redis 异常时降级为直接调用 loader
+	if !errors.Is(err, redis.Nil) {
+		slog.Warn("cache-get", slog.String("key", key), slog.String("error", err.Error()))
+	}
+
+	data, err = c.group.do(key, func() ([]byte, error) {
+		v, err := loader(ctx)
+		if errors.Is(err, ErrNotFound) {
+			if c.opts.NotFoundTTL > 0 {
+				c.trySet(ctx, key, []byte{flagNotFound}, c.opts.NotFoundTTL)
+			}
+
+			return nil, ErrNotFound
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := c.opts.Codec.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append([]byte{flagValue}, data...)
+		c.trySet(ctx, key, data, ttl)
+
+		return data, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return res, c.decode(data, &res)
+}
+
+func (c *Cache) Close() {
+	if c.pubsub == nil {
+		return
+	}
+
+	_ = c.pubsub.Close()
+	c.wg.Wait()
+}
+
+// get 依次读取 L1 与 Redis, 不存在时返回 redis.Nil
+func (c *Cache) get(ctx context.Context, key string) ([]byte, error) {
+	if c.local == nil {
+		return c.client.Get(ctx, c.opts.Prefix+key).Bytes()
+	}
+
+	if data, ok := c.local.get(key); ok {
+		return data, nil
+	}
+
+	// L1 过期时间不超过 Redis 中剩余的 TTL
+	pipe := c.client.Pipeline()
+	get := pipe.Get(ctx, c.opts.Prefix+key)
+	pttl := pipe.PTTL(ctx, c.opts.Prefix+key)
+	_, _ = pipe.Exec(ctx)
+
+	data, err := get.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	c.local.set(key, data, pttl.Val())
+
+	return data, nil
+}
+
+func (c *Cache) set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	ttl = c.jitter(ttl)
+
+	if err := c.client.Set(ctx, c.opts.Prefix+key, data, ttl).Err(); err != nil {
+		return err
+	}
+
+	c.invalidate(ctx, key)
+
+	if c.local != nil {
+		c.local.set(key, data, ttl)
+	}
+
+	return nil
+}
+
+func (c *Cache) trySet(ctx context.Context, key string, data []byte, ttl time.Duration) {
+	if err := c.set(ctx, key, data, ttl); err != nil {
+		slog.Warn("cache-set", slog.String("key", key), slog.String("error", err.Error()))
+	}
+}
+
+func (c *Cache) decode(data []byte, v any) error {
+	if len(data) == 0 || data[0] == flagNotFound {
+		return ErrNotFound
+	}
+
+	return c.opts.Codec.Unmarshal(data[1:], v)
+}
+
+func (c *Cache) jitter(ttl time.Duration) time.Duration {
+	if ttl <= 0 || c.opts.Jitter <= 0 {
+		return ttl
+	}
+
+	return ttl + time.Duration(mrand.Int63n(int64(float64(ttl)*c.opts.Jitter)+1))
+}
+
+// invalidate 通知其他实例清除 L1 缓存, 消息格式: 实例ID|key1|key2
+func (c *Cache) invalidate(ctx context.Context, keys ...string) {
+	if c.local == nil {
+		return
+	}
+
+	c.local.delete(keys...)
+
+	msg := c.id + "|" + strings.Join(keys, "|")
+	if err := c.client.Publish(ctx, c.opts.Channel, msg).Err(); err != nil {
+		slog.Warn("cache-invalidate", slog.String("error", err.Error()))
+	}
+}
+
+func (c *Cache) subscribe() {
+	c.pubsub = c.client.Subscribe(context.Background(), c.opts.Channel)
+
+	// 等待订阅确认, 避免错过订阅建立前的失效通知
+	if _, err := c.pubsub.Receive(context.Background()); err != nil {
+		slog.Warn("cache-subscribe", slog.String("channel", c.opts.Channel), slog.String("error", err.Error()))
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for msg := range c.pubsub.Channel() {
+			parts := strings.Split(msg.Payload, "|")
+			if len(parts) < 2 || parts[0] == c.id {
+				continue
+			}
+
+			c.local.delete(parts[1:]...)
+		}
+	}()
+}
+
+func newInstanceID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+type user struct {
+	ID   int64
+	Name string
+}
+
+func newTestClient(t *testing.T) (*miniredis.Miniredis, redis.UniversalClient) {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return s, client
+}
+
+func TestCodecs(t *testing.T) {
+	for name, codec := range map[string]Codec{"json": JSON, "gob": Gob} {
+		data, err := codec.Marshal(user{ID: 1, Name: "rain"})
+		if err != nil {
+			t.Fatal(name, err)
+		}
+
+		var u user
+		if err = codec.Unmarshal(data, &u); err != nil || u.Name != "rain" {
+			t.Fatalf("%s: unexpected %+v, %v", name, u, err)
+		}
+	}
+
+	data, _ := Binary.Marshal(int64(-42))
+	var n int64
+	if err := Binary.Unmarshal(data, &n); err != nil || n != -42 {
+		t.Fatalf("binary: unexpected %d, %v", n, err)
+	}
+
+	if _, err := Binary.Marshal(user{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("binary: expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestRemember(t *testing.T) {
+	_, client := newTestClient(t)
+	c := New(client, Options{Prefix: "test:", Jitter: 0.1, NotFoundTTL: time.Minute})
+
+	var calls atomic.Int32
+	loader := func(ctx context.Context) (user, error) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return user{ID: 1, Name: "rain"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if u, err := Remember(context.Background(), c, "user:1", time.Minute, loader); err != nil || u.Name != "rain" {
+				t.Errorf("unexpected %+v, %v", u, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("loader called %d times", n)
+	}
+
+	notFound := func(ctx context.Context) (user, error) {
+		calls.Add(1)
+		return user{}, ErrNotFound
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := Remember(context.Background(), c, "user:2", time.Minute, notFound); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("negative cache not used, loader called %d times", n)
+	}
+
+	var u user
+	if err := c.Get(context.Background(), "user:2", &u); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for negative cache, got %v", err)
+	}
+
+	if err := c.Get(context.Background(), "user:3", &u); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound on miss, got %v", err)
+	}
+}
+
+func TestLocalInvalidation(t *testing.T) {
+	_, client := newTestClient(t)
+
+	a := New(client, Options{LocalSize: 10})
+	b := New(client, Options{LocalSize: 10})
+	defer a.Close()
+	defer b.Close()
+
+	ctx := context.Background()
+	_ = a.Set(ctx, "k", "v1", time.Minute)
+
+	var v string
+	if err := b.Get(ctx, "k", &v); err != nil || v != "v1" {
+		t.Fatalf("unexpected %q, %v", v, err)
+	}
+
+	_ = a.Set(ctx, "k", "v2", time.Minute)
+
+	for i := 0; i < 50; i++ {
+		if _ = b.Get(ctx, "k", &v); v == "v2" {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("local cache not invalidated, got %q", v)
+}
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	JSON   Codec = jsonCodec{}
+	Gob    Codec = gobCodec{}
+	Binary Codec = binaryCodec{}
+)
+
+var ErrUnsupportedType = errors.New("cache: unsupported type")
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// binaryCodec 支持 []byte, string, bool, 整型, 浮点型及实现了
+// encoding.BinaryMarshaler / encoding.BinaryUnmarshaler 的类型, 整型统一以 8 字节大端存储
+type binaryCodec struct{}
+
+func (binaryCodec) Marshal(v any) ([]byte, error) {
+	switch t := v.(type) {
+	case []byte:
+		return t, nil
+	case string:
+		return []byte(t), nil
+	case bool:
+		if t {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case int:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case int8:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case int16:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case int32:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case int64:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case uint:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case uint8:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case uint16:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case uint32:
+		return binary.BigEndian.AppendUint64(nil, uint64(t)), nil
+	case uint64:
+		return binary.BigEndian.AppendUint64(nil, t), nil
+	case float32:
+		return binary.BigEndian.AppendUint64(nil, math.Float64bits(float64(t))), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(nil, math.Float64bits(t)), nil
+	case encoding.BinaryMarshaler:
+		return t.MarshalBinary()
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+}
+
+func (binaryCodec) Unmarshal(data []byte, v any) error {
+	if u, ok := v.(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(data)
+	}
+
+	switch t := v.(type) {
+	case *[]byte:
+		*t = append((*t)[:0], data...)
+		return nil
+	case *string:
+		*t = string(data)
+		return nil
+	case *bool:
+		*t = len(data) > 0 && data[0] == 1
+		return nil
+	}
+
+	if len(data) != 8 {
+		return fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+	}
+
+	n := binary.BigEndian.Uint64(data)
+
+	switch t := v.(type) {
+	case *int:
+		*t = int(n)
+	case *int8:
+		*t = int8(n)
+	case *int16:
+		*t = int16(n)
+	case *int32:
+		*t = int32(n)
+	case *int64:
+		*t = int64(n)
+	case *uint:
+		*t = uint(n)
+	case *uint8:
+		*t = uint8(n)
+	case *uint16:
+		*t = uint16(n)
+	case *uint32:
+		*t = uint32(n)
+	case *uint64:
+		*t = n
+	case *float32:
+		*t = float32(math.Float64frombits(n))
+	case *float64:
+		*t = math.Float64frombits(n)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key    string
+	data   []byte
+	expire time.Time
+}
+
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+func newLRU(size int, ttl time.Duration) *lru {
+	return &lru{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+func (l *lru) get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := e.Value.(*lruEntry)
+	if time.Now().After(entry.expire) {
+		l.ll.Remove(e)
+		delete(l.items, key)
+
+		return nil, false
+	}
+
+	l.ll.MoveToFront(e)
+
+	return entry.data, true
+}
+
+func (l *lru) set(key string, data []byte, ttl time.Duration) {
+	if ttl <= 0 || ttl > l.ttl {
+		ttl = l.ttl
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := l.items[key]; ok {
+		entry := e.Value.(*lruEntry)
+		entry.data = data
+		entry.expire = time.Now().Add(ttl)
+		l.ll.MoveToFront(e)
+
+		return
+	}
+
+	l.items[key] = l.ll.PushFront(&lruEntry{key: key, data: data, expire: time.Now().Add(ttl)})
+
+	for l.ll.Len() > l.size {
+		e := l.ll.Back()
+		l.ll.Remove(e)
+		delete(l.items, e.Value.(*lruEntry).key)
+	}
+}
+
+func (l *lru) delete(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if e, ok := l.items[key]; ok {
+			l.ll.Remove(e)
+			delete(l.items, key)
+		}
+	}
+}
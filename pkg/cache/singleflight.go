@@ -0,0 +1,46 @@
+package cache
+
+import "sync"
+
+type call struct {
+	wg   sync.WaitGroup
+	data []byte
+	err  error
+}
+
+// group 合并相同 key 的并发加载, 只有第一个调用者执行 fn, 其余等待并共享结果
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+func (g *group) do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*call{}
+	}
+
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+
+		return c.data, c.err
+	}
+
+	c := &call{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		c.wg.Done()
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+	}()
+
+	c.data, c.err = fn()
+
+	return c.data, c.err
+}
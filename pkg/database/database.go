@@ -17,12 +17,16 @@ const (
 	TypSQLLite3  = "sqllite3"
 )
 
+// drainTimeout 等待旧连接池执行中查询完成的最长时间
+const drainTimeout = 30 * time.Second
+
 type dbInstance struct {
-	db  *sql.DB
-	orm *orm.Orm
+	config Config
+	orm    *orm.Orm
 }
 
 type Database struct {
+	mu   sync.Mutex
 	list sync.Map // name => *dbInstance
 }
 
@@ -70,13 +74,11 @@ func (m *Database) create(c *Config) error {
 		return fmt.Errorf("orm open connect (%s) error: %v", c.Name, err)
 	}
 
-	sdb := db.DB()
-
-	m.setOptions(sdb, c)
+	m.setOptions(db.DB(), c)
 
 	m.list.Store(c.Name, &dbInstance{
-		db:  sdb,
-		orm: db,
+		config: *c,
+		orm:    db,
 	})
 
 	return nil
@@ -108,25 +110,97 @@ func (m *Database) Get(name ...string) (*orm.Orm, error) {
 	return nil, fmt.Errorf("数据库资源未找到: %s", name[0])
 }
 
+// UpdateConfig 新增或移除数据库, 连接地址变化时替换连接池, 已获取的 *orm.Orm 继续可用,
+// 旧连接池在执行中的查询完成后关闭, 存在失败时返回 false
 func (m *Database) UpdateConfig(c []Config) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	next := map[string]bool{}
+
 	for _, v := range c {
 		v := v
+
+		if v.Disable {
+			continue
+		}
+
 		v.validate()
+		next[v.Name] = true
+
+		tmp, exists := m.list.Load(v.Name)
+		if !exists {
+			if err := m.create(&v); err != nil {
+				logger.M().Error("初始化数据库异常", slog.String("name", v.Name), slog.String("error", err.Error()))
+				ok = false
+
+				continue
+			}
+
+			logger.M().Info(fmt.Sprintf("添加数据库 %s", v.Name))
 
-		tmp, ok := m.list.Load(v.Name)
-		if !ok {
 			continue
 		}
 
-		m.setOptions(tmp.(*dbInstance).db, &v)
+		ins := tmp.(*dbInstance)
+
+		if ins.config.Type != v.Type || ins.config.Addr != v.Addr {
+			old, err := ins.orm.Reconnect(v.Type, v.Addr)
+			if err != nil {
+				logger.M().Error("连接数据库异常", slog.String("name", v.Name), slog.String("error", err.Error()))
+				ok = false
+
+				continue
+			}
+
+			go func(name string) {
+				waitIdle(old)
+
+				if err := old.Close(); err != nil {
+					logger.M().Error("关闭数据库异常", slog.String(name, err.Error()))
+				}
+			}(v.Name)
+
+			logger.M().Info(fmt.Sprintf("更新数据库连接 %s", v.Name))
+		}
+
+		m.setOptions(ins.orm.DB(), &v)
+		ins.config = v
 	}
 
-	return true
+	m.list.Range(func(key, v any) bool {
+		if next[key.(string)] {
+			return true
+		}
+
+		m.list.Delete(key)
+
+		go func(o *orm.Orm) {
+			waitIdle(o.DB())
+			o.Close()
+		}(v.(*dbInstance).orm)
+
+		logger.M().Info(fmt.Sprintf("移除数据库 %s", key))
+
+		return true
+	})
+
+	return ok
+}
+
+// waitIdle 等待执行中的查询及事务完成, 最长 drainTimeout
+func waitIdle(db *sql.DB) {
+	deadline := time.Now().Add(drainTimeout)
+
+	for time.Now().Before(deadline) && db.Stats().InUse > 0 {
+		time.Sleep(100 * time.Millisecond)
+	}
 }
 
 func (m *Database) Close() {
 	m.list.Range(func(key, v any) bool {
-		if err := v.(*dbInstance).db.Close(); err != nil {
+		if err := v.(*dbInstance).orm.DB().Close(); err != nil {
 			logger.M().Error("关闭数据库异常", slog.String(key.(string), err.Error()))
 		}
 
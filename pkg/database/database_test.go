@@ -0,0 +1,140 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yrbb/rain/pkg/logger"
+	"github.com/yrbb/rain/pkg/logger/writer"
+	"github.com/yrbb/rain/pkg/orm"
+)
+
+// fakeDriver 连接地址以 bad 开头时连接失败
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	if strings.HasPrefix(name, "bad") {
+		return nil, errors.New("connect refused")
+	}
+
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func init() {
+	sql.Register("fake", fakeDriver{})
+}
+
+func TestMain(m *testing.M) {
+	logger.Init("test", "error", logger.Sink{Writer: writer.NewFileWriter("test", os.TempDir(), writer.Options{SplitTime: 60})})
+
+	code := m.Run()
+	logger.Close()
+
+	os.Exit(code)
+}
+
+func fakeConfig(name, addr string) Config {
+	return Config{Config: orm.Config{Name: name, Type: "fake", Addr: addr, PoolThreshold: -1}}
+}
+
+func TestUpdateConfig(t *testing.T) {
+	m, err := New([]Config{fakeConfig("a", "a1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	a, err := m.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldDB := a.DB()
+
+	// 替换 a 的连接地址, 新增 b
+	if !m.UpdateConfig([]Config{fakeConfig("a", "a2"), fakeConfig("b", "b1")}) {
+		t.Fatal("update should succeed")
+	}
+
+	if a2, _ := m.Get("a"); a2 != a || a.Config().Addr != "a2" || a.DB() == oldDB {
+		t.Fatalf("a not reconnected: %+v", a.Config())
+	}
+
+	if _, err = m.Get("b"); err != nil {
+		t.Fatal(err)
+	}
+
+	// b 连接失败时保留原连接, 移除 a
+	if m.UpdateConfig([]Config{fakeConfig("b", "bad")}) {
+		t.Fatal("update should fail")
+	}
+
+	if _, err = m.Get("a"); err == nil {
+		t.Fatal("a should be removed")
+	}
+
+	if b, _ := m.Get("b"); b == nil || b.Config().Addr != "b1" {
+		t.Fatal("b should keep the old connection")
+	}
+
+	// 新增连接失败
+	if m.UpdateConfig([]Config{fakeConfig("b", "b1"), fakeConfig("c", "bad")}) {
+		t.Fatal("update should fail")
+	}
+
+	if _, err = m.Get("c"); err == nil {
+		t.Fatal("c should not be added")
+	}
+}
+
+func TestReconnectRace(t *testing.T) {
+	m, err := New([]Config{fakeConfig("a", "a0")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	a, _ := m.Get("a")
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = a.Config().Addr
+				_ = a.DB().Stats()
+			}
+		}
+	}()
+
+	for _, addr := range []string{"a1", "a2", "a3"} {
+		if !m.UpdateConfig([]Config{fakeConfig("a", addr)}) {
+			t.Fatal("update should succeed")
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+
+	if a.Config().Addr != "a3" {
+		t.Fatalf("unexpected addr %s", a.Config().Addr)
+	}
+}
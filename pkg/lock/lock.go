@@ -0,0 +1,238 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrNotObtained 锁已被其他实例持有
+	ErrNotObtained = errors.New("lock: not obtained")
+
+	// ErrNotHeld 锁已过期或被其他实例持有
+	ErrNotHeld = errors.New("lock: not held")
+)
+
+// releaseScript 仅当 token 一致时删除, 避免误删其他实例的锁
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// refreshScript 仅当 token 一致时续期
+var refreshScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+type Options struct {
+	TTL             time.Duration // 租约时间, 默认 30s
+	RefreshInterval time.Duration // 自动续期间隔, 默认 TTL/3, 小于 0 时不自动续期
+	RetryInterval   time.Duration // Lock 重试间隔, 默认 100ms
+	Prefix          string        // key 前缀, 默认 lock:
+}
+
+type Locker struct {
+	client redis.UniversalClient
+	opts   Options
+}
+
+func New(client redis.UniversalClient, opts Options) *Locker {
+	if opts.TTL <= 0 {
+		opts.TTL = 30 * time.Second
+	}
+
+	if opts.RefreshInterval == 0 {
+		opts.RefreshInterval = opts.TTL / 3
+	}
+
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = 100 * time.Millisecond
+	}
+
+	if opts.Prefix == "" {
+		opts.Prefix = "lock:"
+	}
+
+	return &Locker{client: client, opts: opts}
+}
+
+// TryLock 尝试获取锁, 已被持有时返回 ErrNotObtained
+func (l *Locker) TryLock(ctx context.Context, key string) (*Lock, error) {
+	token := newToken()
+	start := time.Now()
+
+	ok, err := l.client.SetNX(ctx, l.opts.Prefix+key, token, l.opts.TTL).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrNotObtained
+	}
+
+	lock := &Lock{
+		locker: l,
+		key:    l.opts.Prefix + key,
+		token:  token,
+		stopCh: make(chan struct{}),
+	}
+
+	lock.ctx, lock.cancel = context.WithCancel(context.Background())
+
+	if l.opts.RefreshInterval > 0 {
+		go lock.keepAlive(start.Add(l.opts.TTL))
+	}
+
+	return lock, nil
+}
+
+// Lock 阻塞直到获取锁或 ctx 结束
+func (l *Locker) Lock(ctx context.Context, key string) (*Lock, error) {
+	ticker := time.NewTicker(l.opts.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		lock, err := l.TryLock(ctx, key)
+		if !errors.Is(err, ErrNotObtained) {
+			return lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type Lock struct {
+	locker *Locker
+	key    string
+	token  string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+	stopCh chan struct{}
+}
+
+// Context 在锁释放或租约丢失时取消
+func (l *Lock) Context() context.Context {
+	return l.ctx
+}
+
+func (l *Lock) Key() string {
+	return l.key
+}
+
+func (l *Lock) Refresh(ctx context.Context) error {
+	res, err := refreshScript.Run(ctx, l.locker.client, []string{l.key}, l.token, l.locker.opts.TTL.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+
+	if res == 0 {
+		return ErrNotHeld
+	}
+
+	return nil
+}
+
+func (l *Lock) Release(ctx context.Context) error {
+	l.stop()
+
+	res, err := releaseScript.Run(ctx, l.locker.client, []string{l.key}, l.token).Int64()
+	if err != nil {
+		return err
+	}
+
+	if res == 0 {
+		return ErrNotHeld
+	}
+
+	return nil
+}
+
+// ReleaseAfter 停止自动续期, 锁在 d 后过期, d <= 0 时立即释放
+func (l *Lock) ReleaseAfter(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return l.Release(ctx)
+	}
+
+	l.stop()
+
+	res, err := refreshScript.Run(ctx, l.locker.client, []string{l.key}, l.token, d.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+
+	if res == 0 {
+		return ErrNotHeld
+	}
+
+	return nil
+}
+
+func (l *Lock) stop() {
+	l.once.Do(func() {
+		close(l.stopCh)
+		l.cancel()
+	})
+}
+
+// keepAlive 定期续期, expireAt 为租约过期时间的下限 (发起请求的时间加 TTL).
+// 确认租约丢失, 或续期失败且下次续期前租约会过期时取消 Context, 保证在其他实例获取锁之前停止
+func (l *Lock) keepAlive(expireAt time.Time) {
+	interval := l.locker.opts.RefreshInterval
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		start := time.Now()
+
+		// 续期请求不能超过剩余的租约时间
+		ctx, cancel := context.WithTimeout(context.Background(), min(interval, time.Until(expireAt)))
+		err := l.Refresh(ctx)
+		cancel()
+
+		if err == nil {
+			expireAt = start.Add(l.locker.opts.TTL)
+			continue
+		}
+
+		if errors.Is(err, ErrNotHeld) || time.Until(expireAt) <= interval {
+			slog.Error("lock-lost", slog.String("key", l.key), slog.String("error", err.Error()))
+			l.stop()
+
+			return
+		}
+
+		slog.Warn("lock-refresh", slog.String("key", l.key), slog.String("error", err.Error()))
+	}
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
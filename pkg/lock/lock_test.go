@@ -0,0 +1,85 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestLock(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	locker := New(client, Options{TTL: time.Second, RefreshInterval: 20 * time.Millisecond})
+
+	l, err := locker.TryLock(ctx, "job")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = locker.TryLock(ctx, "job"); !errors.Is(err, ErrNotObtained) {
+		t.Fatalf("expected ErrNotObtained, got %v", err)
+	}
+
+	if err = l.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.Context().Err() == nil {
+		t.Fatal("context should be canceled after release")
+	}
+
+	if err = l.Release(ctx); !errors.Is(err, ErrNotHeld) {
+		t.Fatalf("expected ErrNotHeld, got %v", err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	if l, err = locker.Lock(timeout, "job"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 模拟租约被其他实例抢占
+	s.Set("lock:job", "other")
+
+	select {
+	case <-l.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("context should be canceled after lease lost")
+	}
+}
+
+func TestLockLostBeforeExpire(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr(), MaxRetries: -1})
+	defer client.Close()
+
+	ttl := 300 * time.Millisecond
+	locker := New(client, Options{TTL: ttl, RefreshInterval: 100 * time.Millisecond})
+
+	start := time.Now()
+
+	l, err := locker.TryLock(context.Background(), "job")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 续期全部失败, 需在 key 过期前取消, 避免与新的持有者同时执行
+	s.SetError("ERR unavailable")
+
+	select {
+	case <-l.Context().Done():
+		if elapsed := time.Since(start); elapsed >= ttl {
+			t.Fatalf("context canceled after lease expired: %s", elapsed)
+		}
+	case <-time.After(2 * ttl):
+		t.Fatal("context should be canceled before lease expires")
+	}
+}
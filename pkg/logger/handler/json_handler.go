@@ -12,8 +12,9 @@ import (
 )
 
 type JSONOptions struct {
-	Level  *slog.LevelVar
-	Writer writer.Writer
+	Level    *slog.LevelVar
+	Writer   writer.Writer
+	Redactor *Redactor // 脱敏, 为空时不脱敏
 }
 
 func (o JSONOptions) NewJSONHandler() slog.Handler {
@@ -72,7 +73,7 @@ func (h *JSONHandler) formatter(r *slog.Record) map[string]any {
 		"message":   r.Message,
 	}
 
-	for k, v := range attrsToValue(h.attrs) {
+	for k, v := range attrsToValue(h.attrs, h.option.Redactor) {
 		log[k] = v
 	}
 
@@ -85,7 +86,7 @@ func (h *JSONHandler) formatter(r *slog.Record) map[string]any {
 
 	fields := map[string]any{}
 	r.Attrs(func(attr slog.Attr) bool {
-		for k, v := range attrsToValue([]slog.Attr{attr}) {
+		for k, v := range attrsToValue([]slog.Attr{attr}, h.option.Redactor) {
 			if k != "!BADKEY" {
 				fields[k] = v
 				continue
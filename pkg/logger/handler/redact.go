@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactKeys 默认脱敏的字段名, 字段名包含其中任一项时脱敏 (不区分大小写)
+var DefaultRedactKeys = []string{"password", "passwd", "token", "secret", "card", "authorization"}
+
+const defaultMask = "******"
+
+// Secret 敏感字符串, 写入日志时显示为 ******
+type Secret string
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(defaultMask)
+}
+
+func (s Secret) String() string {
+	return defaultMask
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(defaultMask), nil
+}
+
+type RedactOptions struct {
+	Keys   []string         // 字段名包含其中任一项时整个值脱敏 (不区分大小写), 包括分组
+	Values []*regexp.Regexp // 字符串值中匹配的部分脱敏
+	Mask   string           // 默认 ******
+}
+
+func (o RedactOptions) NewRedactor() *Redactor {
+	if o.Mask == "" {
+		o.Mask = defaultMask
+	}
+
+	keys := make([]string, len(o.Keys))
+	for i, k := range o.Keys {
+		keys[i] = strings.ToLower(k)
+	}
+
+	return &Redactor{keys: keys, values: o.Values, mask: o.Mask}
+}
+
+// Redactor 日志脱敏, 为 nil 时不脱敏
+type Redactor struct {
+	keys   []string
+	values []*regexp.Regexp
+	mask   string
+}
+
+func (r *Redactor) matchKey(key string) bool {
+	if r == nil || len(r.keys) == 0 {
+		return false
+	}
+
+	key = strings.ToLower(key)
+	for _, k := range r.keys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Redactor) redactString(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, re := range r.values {
+		s = re.ReplaceAllString(s, r.mask)
+	}
+
+	return s
+}
+
+// redactAny 脱敏字符串及字符串切片, 如 SQL 及 Redis 命令的参数
+func (r *Redactor) redactAny(v any) any {
+	if r == nil || len(r.values) == 0 {
+		return v
+	}
+
+	switch val := v.(type) {
+	case string:
+		return r.redactString(val)
+	case []string:
+		res := make([]string, len(val))
+		for i := range val {
+			res[i] = r.redactString(val[i])
+		}
+
+		return res
+	case []any:
+		res := make([]any, len(val))
+		for i := range val {
+			if s, ok := val[i].(string); ok {
+				res[i] = r.redactString(s)
+			} else {
+				res[i] = val[i]
+			}
+		}
+
+		return res
+	}
+
+	return v
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type bufWriter struct {
+	data []byte
+}
+
+func (w *bufWriter) Write(_ slog.Level, data []byte) error {
+	w.data = append(w.data, data...)
+	return nil
+}
+
+func (w *bufWriter) Close() {}
+
+func TestRedact(t *testing.T) {
+	rd := RedactOptions{
+		Keys:   DefaultRedactKeys,
+		Values: []*regexp.Regexp{regexp.MustCompile(`1[3-9]\d{9}`)},
+	}.NewRedactor()
+
+	json, text := &bufWriter{}, &bufWriter{}
+	level := &slog.LevelVar{}
+
+	log := slog.New(NewMultiHandler(
+		JSONOptions{Level: level, Writer: json, Redactor: rd}.NewJSONHandler(),
+		TextOptions{Level: level, Writer: text, Redactor: rd, NoColor: true}.NewTextHandler(),
+	))
+
+	log.With(slog.String("access_token", "t0")).Info(
+		"login",
+		slog.String("user", "bob"),
+		slog.String("Password", "p1"),
+		slog.Group("req", slog.String("x-auth-token", "t1"), slog.String("mobile", "13800138000")),
+		slog.Any("args", []any{"13900139000", 1}),
+		slog.Any("key", Secret("s1")),
+	)
+
+	for name, out := range map[string]string{"json": string(json.data), "text": string(text.data)} {
+		if !strings.Contains(out, "bob") {
+			t.Fatalf("%s: unexpected output %s", name, out)
+		}
+
+		for _, secret := range []string{"p1", "t1", "s1", "13800138000", "13900139000"} {
+			if strings.Contains(out, secret) {
+				t.Fatalf("%s: %s not redacted in %s", name, secret, out)
+			}
+		}
+	}
+
+	if strings.Contains(string(json.data), "t0") {
+		t.Fatalf("json: attrs not redacted in %s", json.data)
+	}
+}
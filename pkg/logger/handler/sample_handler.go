@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type SampleOptions struct {
+	Handler slog.Handler
+
+	// 采样: 每个周期内相同级别及消息的前 First 条全部输出, 之后每 Thereafter 条输出 1 条
+	Interval   time.Duration // 默认 1s
+	First      int           // 0 不采样
+	Thereafter int           // 0 丢弃超出 First 的日志
+
+	// 去重: 周期内级别, 消息及字段完全相同的日志只输出第一条, 周期结束时输出 "repeated X times" 汇总
+	Dedup         bool
+	DedupInterval time.Duration // 默认 10s
+}
+
+func (o SampleOptions) NewSampleHandler() *SampleHandler {
+	if o.Handler == nil {
+		panic("missing handler")
+	}
+
+	if o.Interval <= 0 {
+		o.Interval = time.Second
+	}
+
+	if o.DedupInterval <= 0 {
+		o.DedupInterval = 10 * time.Second
+	}
+
+	s := &sampleState{
+		opts:    o,
+		counts:  map[string]int{},
+		repeats: map[string]*repeat{},
+		stopCh:  make(chan struct{}),
+		exitCh:  make(chan struct{}),
+	}
+
+	if o.Dedup {
+		go s.loop()
+	} else {
+		close(s.exitCh)
+	}
+
+	return &SampleHandler{state: s, handler: o.Handler}
+}
+
+var _ slog.Handler = &SampleHandler{}
+
+// SampleHandler 对日志采样及去重后交给 Handler 处理, WithAttrs, WithGroup 返回的 handler 共享计数
+type SampleHandler struct {
+	state   *sampleState
+	handler slog.Handler
+	scope   string // WithAttrs, WithGroup 的内容, 区分不同 handler 的相同日志
+}
+
+type sampleState struct {
+	opts SampleOptions
+
+	mu      sync.Mutex
+	start   time.Time      // 当前采样周期的开始时间
+	counts  map[string]int // 级别及消息 => 周期内的条数
+	repeats map[string]*repeat
+
+	sampled atomic.Int64
+	closed  atomic.Bool
+	stopCh  chan struct{}
+	exitCh  chan struct{}
+}
+
+type repeat struct {
+	handler slog.Handler
+	record  slog.Record
+	first   time.Time
+	count   int
+}
+
+func (h *SampleHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.handler.Enabled(ctx, l)
+}
+
+func (h *SampleHandler) Handle(ctx context.Context, r slog.Record) error {
+	s := h.state
+	opts := s.opts
+
+	if opts.Dedup && s.duplicate(h, &r) {
+		return nil
+	}
+
+	if opts.First > 0 && !s.sample(h.scope+r.Level.String()+"|"+r.Message, r.Time) {
+		s.sampled.Add(1)
+		return nil
+	}
+
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *SampleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	scope := h.scope
+	for _, attr := range attrs {
+		scope += attr.String() + " "
+	}
+
+	return &SampleHandler{state: h.state, handler: h.handler.WithAttrs(attrs), scope: scope}
+}
+
+func (h *SampleHandler) WithGroup(name string) slog.Handler {
+	return &SampleHandler{state: h.state, handler: h.handler.WithGroup(name), scope: h.scope + name + "."}
+}
+
+// Sampled 返回采样丢弃的日志条数
+func (h *SampleHandler) Sampled() int64 {
+	return h.state.sampled.Load()
+}
+
+// Close 输出未结束周期的去重汇总
+func (h *SampleHandler) Close() {
+	s := h.state
+	if s.closed.Swap(true) {
+		return
+	}
+
+	close(s.stopCh)
+	<-s.exitCh
+
+	s.flush(time.Time{})
+}
+
+func (s *sampleState) sample(key string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.start) >= s.opts.Interval {
+		s.start = now
+		clear(s.counts)
+	}
+
+	s.counts[key]++
+	n := s.counts[key]
+
+	if n <= s.opts.First {
+		return true
+	}
+
+	return s.opts.Thereafter > 0 && (n-s.opts.First)%s.opts.Thereafter == 0
+}
+
+// duplicate 返回周期内是否已输出过相同的日志
+func (s *sampleState) duplicate(h *SampleHandler, r *slog.Record) bool {
+	key := h.scope + r.Level.String() + "|" + r.Message + "|"
+	r.Attrs(func(attr slog.Attr) bool {
+		key += attr.String() + " "
+		return true
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.repeats[key]; ok {
+		v.count++
+		return true
+	}
+
+	s.repeats[key] = &repeat{handler: h.handler, record: r.Clone(), first: r.Time}
+
+	return false
+}
+
+func (s *sampleState) loop() {
+	defer close(s.exitCh)
+
+	ticker := time.NewTicker(s.opts.DedupInterval / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case now := <-ticker.C:
+			s.flush(now.Add(-s.opts.DedupInterval))
+		}
+	}
+}
+
+// flush 输出 before 之前开始的去重汇总, before 为零值时输出全部
+func (s *sampleState) flush(before time.Time) {
+	var expired []*repeat
+
+	s.mu.Lock()
+	for key, v := range s.repeats {
+		if before.IsZero() || v.first.Before(before) {
+			delete(s.repeats, key)
+
+			if v.count > 0 {
+				expired = append(expired, v)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, v := range expired {
+		r := slog.NewRecord(time.Now(), v.record.Level, fmt.Sprintf("%s (repeated %d times)", v.record.Message, v.count), v.record.PC)
+		v.record.Attrs(func(attr slog.Attr) bool {
+			r.AddAttrs(attr)
+			return true
+		})
+		r.AddAttrs(slog.Int("repeated", v.count))
+
+		_ = v.handler.Handle(context.Background(), r)
+	}
+}
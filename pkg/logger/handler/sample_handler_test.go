@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, r)
+
+	return nil
+}
+
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *recordHandler) WithGroup(string) slog.Handler { return h }
+
+func TestSampleHandler(t *testing.T) {
+	out := &recordHandler{}
+	h := SampleOptions{Handler: out, Interval: time.Hour, First: 2, Thereafter: 3}.NewSampleHandler()
+	defer h.Close()
+
+	log := slog.New(NewMultiHandler(h))
+	for i := 0; i < 10; i++ {
+		log.Error("db down", slog.Int("i", i))
+	}
+	log.Info("other")
+
+	// 前 2 条, 之后第 5, 8 条, 以及不同消息的 1 条
+	if len(out.records) != 5 || h.Sampled() != 6 {
+		t.Fatalf("%d records, %d sampled", len(out.records), h.Sampled())
+	}
+}
+
+func TestDedupHandler(t *testing.T) {
+	out := &recordHandler{}
+	h := SampleOptions{Handler: out, Dedup: true, DedupInterval: time.Hour}.NewSampleHandler()
+
+	log := slog.New(h)
+	for i := 0; i < 5; i++ {
+		log.Error("db down", slog.String("addr", "127.0.0.1"))
+	}
+	log.Error("db down", slog.String("addr", "127.0.0.2"))
+	log.With(slog.String("logger", "orm")).Error("db down", slog.String("addr", "127.0.0.1"))
+
+	if len(out.records) != 3 {
+		t.Fatalf("%d records before close", len(out.records))
+	}
+
+	h.Close()
+
+	if len(out.records) != 4 || out.records[3].Message != "db down (repeated 4 times)" {
+		t.Fatalf("unexpected summary %+v", out.records)
+	}
+}
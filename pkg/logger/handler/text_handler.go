@@ -24,8 +24,10 @@ const (
 )
 
 type TextOptions struct {
-	Level  *slog.LevelVar
-	Writer writer.Writer
+	Level    *slog.LevelVar
+	Writer   writer.Writer
+	NoColor  bool      // 不输出颜色, 写入文件等非终端时使用
+	Redactor *Redactor // 脱敏, 为空时不脱敏
 }
 
 func (o TextOptions) NewTextHandler() slog.Handler {
@@ -78,7 +80,7 @@ func (h *TextHandler) Handle(ctx context.Context, r slog.Record) error {
 
 	// if len(h.attrs) > 0 {
 	// 	fields := map[string]any{}
-	// 	for k, v := range attrsToValue(h.attrs) {
+	// 	for k, v := range attrsToValue(h.attrs, h.option.Redactor) {
 	// 		fields[k] = v
 	// 	}
 	//
@@ -94,7 +96,7 @@ func (h *TextHandler) Handle(ctx context.Context, r slog.Record) error {
 	// write attributes
 	fields := map[string]any{}
 	r.Attrs(func(attr slog.Attr) bool {
-		for k, v := range attrsToValue([]slog.Attr{attr}) {
+		for k, v := range attrsToValue([]slog.Attr{attr}, h.option.Redactor) {
 			if k != "!BADKEY" {
 				fields[k] = v
 				continue
@@ -150,9 +152,9 @@ func (h *TextHandler) WithGroup(name string) slog.Handler {
 }
 
 func (h *TextHandler) appendTime(buf *buffer, t time.Time) {
-	buf.WriteString(ansiFaint)
+	h.color(buf, ansiFaint)
 	*buf = t.AppendFormat(*buf, "01-02 15:04:05")
-	buf.WriteString(ansiReset)
+	h.color(buf, ansiReset)
 }
 
 func (h *TextHandler) appendLevel(buf *buffer, level slog.Level) {
@@ -169,29 +171,33 @@ func (h *TextHandler) appendLevel(buf *buffer, level slog.Level) {
 		buf.WriteString("DEBUG")
 		delta(buf, level-slog.LevelDebug)
 	case level < slog.LevelWarn:
-		buf.WriteString(ansiBrightGreen)
+		h.color(buf, ansiBrightGreen)
 		buf.WriteString("INFO")
 		delta(buf, level-slog.LevelInfo)
-		buf.WriteString(ansiReset)
+		h.color(buf, ansiReset)
 	case level < slog.LevelError:
-		buf.WriteString(ansiBrightYellow)
+		h.color(buf, ansiBrightYellow)
 		buf.WriteString("WARN")
 		delta(buf, level-slog.LevelWarn)
-		buf.WriteString(ansiReset)
+		h.color(buf, ansiReset)
 	default:
-		buf.WriteString(ansiBrightRed)
+		h.color(buf, ansiBrightRed)
 		buf.WriteString("ERROR")
 		delta(buf, level-slog.LevelError)
-		buf.WriteString(ansiReset)
+		h.color(buf, ansiReset)
 	}
 }
 
 func (h *TextHandler) appendSource(buf *buffer, f runtime.Frame) {
 	_, file := filepath.Split(f.File)
 
-	buf.WriteString(ansiFaint)
+	h.color(buf, ansiFaint)
 	buf.WriteString(file)
 	buf.WriteByte(':')
 	buf.WriteString(strconv.Itoa(f.Line))
-	buf.WriteString(ansiReset)
+	h.color(buf, ansiReset)
+}
+
+func (h *TextHandler) color(buf *buffer, code string) {
+	buf.WriteStringIf(!h.option.NoColor, code)
 }
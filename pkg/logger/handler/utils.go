@@ -57,29 +57,33 @@ func uniqByLast[T any, U comparable](collection []T, iteratee func(item T) U) []
 	return result
 }
 
-func attrsToValue(attrs []slog.Attr) map[string]any {
+func attrsToValue(attrs []slog.Attr, rd *Redactor) map[string]any {
 	log := map[string]any{}
 
 	for i := range attrs {
-		k, v := attrToValue(attrs[i])
+		k, v := attrToValue(attrs[i], rd)
 		log[k] = v
 	}
 
 	return log
 }
 
-func attrToValue(attr slog.Attr) (string, any) {
+func attrToValue(attr slog.Attr, rd *Redactor) (string, any) {
 	k := attr.Key
-	v := attr.Value
+	v := attr.Value.Resolve()
 	kind := v.Kind()
 
+	if rd.matchKey(k) {
+		return k, rd.mask
+	}
+
 	switch kind {
 	case slog.KindAny:
-		return k, v.Any()
+		return k, rd.redactAny(v.Any())
 	case slog.KindLogValuer:
 		return k, v.Any()
 	case slog.KindGroup:
-		return k, attrsToValue(v.Group())
+		return k, attrsToValue(v.Group(), rd)
 	case slog.KindInt64:
 		return k, v.Int64()
 	case slog.KindUint64:
@@ -87,7 +91,7 @@ func attrToValue(attr slog.Attr) (string, any) {
 	case slog.KindFloat64:
 		return k, v.Float64()
 	case slog.KindString:
-		return k, v.String()
+		return k, rd.redactString(v.String())
 	case slog.KindBool:
 		return k, v.Bool()
 	case slog.KindDuration:
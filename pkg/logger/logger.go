@@ -2,7 +2,6 @@ package logger
 
 import (
 	"log/slog"
-	"runtime"
 	"strings"
 	"time"
 
@@ -11,60 +10,143 @@ import (
 )
 
 var (
-	lWriter writer.Writer
-	gLevel  *slog.LevelVar
-	dLogger *slog.Logger
-	mLogger *slog.Logger
+	lWriters []writer.Writer
+	samplers []*handler.SampleHandler
+	gLevel   = &slog.LevelVar{}
+	floor    = &slog.LevelVar{} // 全局及具名日志中的最低级别, 未单独设置级别的 sink 按该级别过滤
+	dHandler slog.Handler
+	mHandler slog.Handler
+	dLogger  *slog.Logger
+	mLogger  *slog.Logger
+
+	redactor = handler.RedactOptions{Keys: handler.DefaultRedactKeys}.NewRedactor()
 )
 
-func Init(project string, lvl string, path string, splitTime time.Duration) {
+// Secret 敏感字符串, 写入日志时显示为 ******
+type Secret = handler.Secret
+
+// SetRedactor 设置日志脱敏规则, 需在 Init 之前调用, 为 nil 时不脱敏
+func SetRedactor(r *handler.Redactor) {
+	redactor = r
+}
+
+// Sink 日志输出目标
+type Sink struct {
+	Format  string // json, text, 默认 json
+	Level   string // 为空时使用全局级别
+	NoColor bool   // text 格式不输出颜色
+	Writer  writer.Writer
+	Sample  *handler.SampleOptions // 采样及去重, 为空时不启用
+}
+
+func (s Sink) handler(project string) slog.Handler {
+	level := floor
+	if l, ok := ParseLevel(s.Level); ok {
+		level = &slog.LevelVar{}
+		level.Set(l)
+	}
+
+	var h slog.Handler
+	if s.Format == "text" {
+		h = handler.TextOptions{
+			Level:    level,
+			Writer:   s.Writer,
+			NoColor:  s.NoColor,
+			Redactor: redactor,
+		}.NewTextHandler()
+	} else {
+		h = handler.JSONOptions{
+			Level:    level,
+			Writer:   s.Writer,
+			Redactor: redactor,
+		}.NewJSONHandler().WithAttrs([]slog.Attr{slog.String("project", project)})
+	}
+
+	if s.Sample == nil {
+		return h
+	}
+
+	opts := *s.Sample
+	opts.Handler = h
+
+	sampler := opts.NewSampleHandler()
+	samplers = append(samplers, sampler)
+
+	return sampler
+}
+
+// Init 初始化日志, 日志写入全部 sinks, debug 模式下同时输出到终端
+func Init(project string, lvl string, sinks ...Sink) {
 	SetLevel(lvl)
 
-	lWriter = writer.NewFileWriter(project, path, splitTime)
+	handlers := make([]slog.Handler, 0, len(sinks))
+	console := false
 
-	textHandler := handler.TextOptions{
-		Level:  gLevel,
-		Writer: writer.NewConsoleWriter(),
-	}.NewTextHandler()
+	lWriters = lWriters[:0]
+	samplers = samplers[:0]
+	for _, sink := range sinks {
+		handlers = append(handlers, sink.handler(project))
+		lWriters = append(lWriters, sink.Writer)
 
-	jsonHandler := handler.JSONOptions{
-		Level:  gLevel,
-		Writer: lWriter,
-	}.NewJSONHandler().WithAttrs([]slog.Attr{slog.String("project", project)})
+		if _, ok := sink.Writer.(*writer.ConsoleWriter); ok {
+			console = true
+		}
+	}
 
-	dLogger = slog.New(jsonHandler)
-	mLogger = slog.New(handler.NewMultiHandler(textHandler, jsonHandler))
-	slog.SetDefault(dLogger)
+	dHandler = handler.NewMultiHandler(handlers...)
+	mHandler = dHandler
 
-	runtime.SetFinalizer(lWriter, func(w writer.Writer) {
-		w.Close()
-	})
+	// 已输出到终端时不重复输出
+	if !console {
+		textHandler := handler.TextOptions{
+			Level:    floor,
+			Writer:   writer.NewConsoleWriter(),
+			Redactor: redactor,
+		}.NewTextHandler()
+
+		mHandler = handler.NewMultiHandler(append([]slog.Handler{textHandler}, handlers...)...)
+	}
+
+	dLogger = slog.New(&levelHandler{level: gLevel, handler: dHandler})
+	mLogger = slog.New(&levelHandler{level: gLevel, handler: mHandler})
+
+	setRoot(dHandler)
+	slog.SetDefault(dLogger)
 }
 
 func SetDebug(d bool) {
 	if !d {
+		setRoot(dHandler)
 		slog.SetDefault(dLogger)
 		return
 	}
 
+	setRoot(mHandler)
 	slog.SetDefault(mLogger)
 }
 
+// SetLevel 设置全局级别, 未单独设置级别的具名日志同时生效
 func SetLevel(lvl string) {
-	if gLevel == nil {
-		gLevel = &slog.LevelVar{}
+	if l, ok := ParseLevel(lvl); ok {
+		gLevel.Set(l)
+		updateFloor()
 	}
+}
 
+// ParseLevel 解析 debug, info, warn, error
+func ParseLevel(lvl string) (slog.Level, bool) {
 	switch strings.ToLower(lvl) {
 	case "debug":
-		gLevel.Set(slog.LevelDebug)
+		return slog.LevelDebug, true
 	case "info":
-		gLevel.Set(slog.LevelInfo)
+		return slog.LevelInfo, true
 	case "warn":
-		gLevel.Set(slog.LevelWarn)
+		return slog.LevelWarn, true
 	case "error":
-		gLevel.Set(slog.LevelError)
+		return slog.LevelError, true
 	}
+
+	return 0, false
 }
 
 func GetLevel() slog.Level {
@@ -72,7 +154,11 @@ func GetLevel() slog.Level {
 }
 
 func SetSplitTime(st time.Duration) {
-	lWriter.(*writer.LevelWriter).WithSplitTime(st)
+	for _, w := range lWriters {
+		if lw, ok := w.(*writer.LevelWriter); ok {
+			lw.WithSplitTime(st)
+		}
+	}
 }
 
 func M() *slog.Logger {
@@ -80,9 +166,11 @@ func M() *slog.Logger {
 }
 
 func Close() {
-	if lWriter == nil {
-		return
+	for _, s := range samplers {
+		s.Close()
 	}
 
-	lWriter.Close()
+	for _, w := range lWriters {
+		w.Close()
+	}
 }
@@ -0,0 +1,191 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// root 当前 debug 模式下使用的 handler, 具名日志写入时获取, Init 之前创建的具名日志同样生效
+	root atomic.Pointer[slog.Handler]
+
+	namedMu sync.Mutex
+	named   = map[string]*namedLevel{}
+)
+
+func setRoot(h slog.Handler) {
+	root.Store(&h)
+}
+
+// namedLevel 具名日志的级别, 未设置时使用全局级别
+type namedLevel struct {
+	set   atomic.Bool
+	level slog.LevelVar
+}
+
+func (l *namedLevel) Level() slog.Level {
+	if l.set.Load() {
+		return l.level.Level()
+	}
+
+	return gLevel.Level()
+}
+
+func getNamedLevel(name string) *namedLevel {
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	l, ok := named[name]
+	if !ok {
+		l = &namedLevel{}
+		named[name] = l
+	}
+
+	return l
+}
+
+// Named 返回具名日志, 级别可通过 SetNamedLevel 单独设置, 日志带有 logger 字段
+func Named(name string) *slog.Logger {
+	return slog.New(&namedHandler{level: getNamedLevel(name)}).With(slog.String("logger", name))
+}
+
+// SetNamedLevel 设置具名日志的级别, lvl 为空时恢复使用全局级别
+func SetNamedLevel(name string, lvl string) error {
+	l := getNamedLevel(name)
+
+	if lvl == "" {
+		l.set.Store(false)
+	} else {
+		v, ok := ParseLevel(lvl)
+		if !ok {
+			return fmt.Errorf("日志级别 %s 不支持", lvl)
+		}
+
+		l.level.Set(v)
+		l.set.Store(true)
+	}
+
+	updateFloor()
+
+	return nil
+}
+
+// NamedLevels 返回具名日志单独设置的级别, 未设置的为空
+func NamedLevels() map[string]string {
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	levels := make(map[string]string, len(named))
+	for name, l := range named {
+		levels[name] = ""
+		if l.set.Load() {
+			levels[name] = LevelString(l.level.Level())
+		}
+	}
+
+	return levels
+}
+
+// LevelString 返回 debug, info, warn, error
+func LevelString(l slog.Level) string {
+	return strings.ToLower(l.String())
+}
+
+func updateFloor() {
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	lvl := gLevel.Level()
+	for _, l := range named {
+		if l.set.Load() {
+			lvl = min(lvl, l.level.Level())
+		}
+	}
+
+	floor.Set(lvl)
+}
+
+// levelHandler 按 level 过滤后交给 handler 处理
+type levelHandler struct {
+	level   slog.Leveler
+	handler slog.Handler
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= h.level.Level() && h.handler.Enabled(ctx, l)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithAttrs(attrs)}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithGroup(name)}
+}
+
+type namedCache struct {
+	root    *slog.Handler
+	handler slog.Handler
+}
+
+// namedHandler 按具名日志的级别过滤, 写入当前的 root handler
+type namedHandler struct {
+	level *namedLevel
+	ops   []func(slog.Handler) slog.Handler // WithAttrs, WithGroup
+	cache atomic.Pointer[namedCache]
+}
+
+func (h *namedHandler) handler() slog.Handler {
+	r := root.Load()
+	if r == nil {
+		return slog.Default().Handler()
+	}
+
+	if c := h.cache.Load(); c != nil && c.root == r {
+		return c.handler
+	}
+
+	handler := *r
+	for _, op := range h.ops {
+		handler = op(handler)
+	}
+
+	h.cache.Store(&namedCache{root: r, handler: handler})
+
+	return handler
+}
+
+func (h *namedHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= h.level.Level() && h.handler().Enabled(ctx, l)
+}
+
+func (h *namedHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler().Handle(ctx, r)
+}
+
+func (h *namedHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+func (h *namedHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
+
+func (h *namedHandler) with(op func(slog.Handler) slog.Handler) slog.Handler {
+	return &namedHandler{
+		level: h.level,
+		ops:   append(append([]func(slog.Handler) slog.Handler{}, h.ops...), op),
+	}
+}
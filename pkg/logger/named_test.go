@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type memWriter struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (w *memWriter) Write(_ slog.Level, data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lines = append(w.lines, string(data))
+
+	return nil
+}
+
+func (w *memWriter) Close() {}
+
+func TestNamed(t *testing.T) {
+	// Init 之前创建的具名日志同样生效
+	orm := Named("orm")
+
+	w := &memWriter{}
+	Init("test", "warn", Sink{Writer: w})
+
+	if err := SetNamedLevel("orm", "debug"); err != nil {
+		t.Fatal(err)
+	}
+
+	slog.Debug("global-debug")
+	slog.Warn("global-warn")
+	orm.Debug("orm-debug")
+	Named("redis").Info("redis-info")
+
+	if len(w.lines) != 2 || !strings.Contains(w.lines[0], "global-warn") ||
+		!strings.Contains(w.lines[1], `"logger":"orm"`) {
+		t.Fatalf("unexpected lines %q", w.lines)
+	}
+
+	_ = SetNamedLevel("orm", "")
+	orm.Debug("orm-debug")
+
+	if len(w.lines) != 2 {
+		t.Fatalf("unexpected lines %q", w.lines)
+	}
+
+	if levels := NamedLevels(); levels["orm"] != "" || len(levels) != 2 {
+		t.Fatalf("unexpected levels %v", levels)
+	}
+}
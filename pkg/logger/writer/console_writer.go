@@ -8,13 +8,19 @@ import (
 var _ Writer = &ConsoleWriter{}
 
 func NewConsoleWriter() *ConsoleWriter {
-	return &ConsoleWriter{}
+	return &ConsoleWriter{out: os.Stdout}
 }
 
-type ConsoleWriter struct{}
+func NewStderrWriter() *ConsoleWriter {
+	return &ConsoleWriter{out: os.Stderr}
+}
+
+type ConsoleWriter struct {
+	out *os.File
+}
 
 func (c *ConsoleWriter) Write(_ slog.Level, data []byte) error {
-	os.Stdout.Write(data)
+	c.out.Write(data)
 	return nil
 }
 
@@ -1,12 +1,5 @@
 package writer
 
-import (
-	"time"
-)
-
-func NewFileWriter(project, path string, splitTime time.Duration) Writer {
-	w := NewLevelWriter(project, path, splitTime)
-	w.(*LevelWriter).ignoreLevel = true
-
-	return w
+func NewFileWriter(project, path string, opts Options) Writer {
+	return newLevelWriter(project, path, opts, true)
 }
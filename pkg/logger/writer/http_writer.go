@@ -0,0 +1,215 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+var _ Writer = &HTTPWriter{}
+
+type HTTPOptions struct {
+	URL           string
+	Headers       map[string]string
+	BatchSize     int           // 每次发送的最大条数, 默认 100
+	FlushInterval time.Duration // 发送间隔, 默认 1s
+	Timeout       time.Duration // 单次请求超时, 默认 5s
+	MaxRetries    int           // 失败重试次数, 默认 3, 小于 0 不重试
+	Backoff       time.Duration // 首次重试间隔, 之后每次翻倍, 最大 30s, 默认 500ms
+	QueueSize     int           // 待发送的最大条数, 队列满时丢弃新日志, 默认 10000
+	Client        *http.Client
+}
+
+func (o *HTTPOptions) init() {
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = time.Second
+	}
+
+	if o.Timeout <= 0 {
+		o.Timeout = 5 * time.Second
+	}
+
+	if o.MaxRetries == 0 {
+		o.MaxRetries = 3
+	}
+
+	if o.Backoff <= 0 {
+		o.Backoff = 500 * time.Millisecond
+	}
+
+	if o.QueueSize <= 0 {
+		o.QueueSize = 10000
+	}
+
+	if o.Client == nil {
+		o.Client = &http.Client{}
+	}
+}
+
+// HTTPWriter 批量 POST 日志, 请求体为按行分隔的日志 (application/x-ndjson)
+type HTTPWriter struct {
+	opts HTTPOptions
+
+	ch      chan []byte
+	dropped atomic.Int64
+	closed  atomic.Bool
+
+	ctx    context.Context // Close 时取消, 不再等待重试
+	cancel context.CancelFunc
+	exitCh chan struct{}
+}
+
+func NewHTTPWriter(opts HTTPOptions) (*HTTPWriter, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("http 日志地址不能为空")
+	}
+
+	opts.init()
+
+	w := &HTTPWriter{
+		opts:   opts,
+		ch:     make(chan []byte, opts.QueueSize),
+		exitCh: make(chan struct{}),
+	}
+
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+
+	go w.loop()
+
+	return w, nil
+}
+
+func (w *HTTPWriter) Write(_ slog.Level, data []byte) error {
+	if w.closed.Load() {
+		return nil
+	}
+
+	select {
+	case w.ch <- append([]byte(nil), data...):
+	default:
+		w.dropped.Add(1)
+	}
+
+	return nil
+}
+
+// Dropped 返回队列满时丢弃及发送失败的日志条数
+func (w *HTTPWriter) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+func (w *HTTPWriter) loop() {
+	defer close(w.exitCh)
+
+	ticker := time.NewTicker(w.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, w.opts.BatchSize)
+
+	flush := func() {
+		if len(batch) > 0 {
+			w.send(batch)
+			batch = batch[:0]
+		}
+	}
+
+	for {
+		select {
+		case data := <-w.ch:
+			if batch = append(batch, data); len(batch) >= w.opts.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-w.ctx.Done():
+			for {
+				select {
+				case data := <-w.ch:
+					if batch = append(batch, data); len(batch) >= w.opts.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (w *HTTPWriter) send(batch [][]byte) {
+	body := bytes.Join(batch, nil)
+	backoff := w.opts.Backoff
+
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(body)
+		if err == nil {
+			return
+		}
+
+		if !retry || attempt >= w.opts.MaxRetries || w.ctx.Err() != nil {
+			w.dropped.Add(int64(len(batch)))
+			log.Println("发送日志失败:", err.Error())
+
+			return
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-w.ctx.Done():
+			t.Stop()
+		}
+
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
+
+// post 发送一次请求, 返回失败时是否可以重试
+func (w *HTTPWriter) post(body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	for k, v := range w.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.opts.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	err = fmt.Errorf("http status %d", resp.StatusCode)
+
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// Close 发送剩余的日志, 不再等待重试
+func (w *HTTPWriter) Close() {
+	if w.closed.Swap(true) {
+		return
+	}
+
+	w.cancel()
+	<-w.exitCh
+}
@@ -0,0 +1,65 @@
+package writer
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHTTPWriter(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+		lines    []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// 第一次请求失败, 重试后成功
+		if requests++; requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		lines = append(lines, strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")...)
+	}))
+	defer srv.Close()
+
+	w, err := New("http", Config{
+		Address:       srv.URL,
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w.(*HTTPWriter).opts.Backoff = 10 * time.Millisecond
+
+	for _, line := range []string{"a\n", "b\n", "c\n"} {
+		_ = w.Write(slog.LevelInfo, []byte(line))
+	}
+
+	// Close 时发送不足一批的日志
+	time.Sleep(100 * time.Millisecond)
+	w.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if strings.Join(lines, ",") != "a,b,c" || requests != 3 {
+		t.Fatalf("unexpected lines %v after %d requests", lines, requests)
+	}
+
+	if n := w.(*HTTPWriter).Dropped(); n != 0 {
+		t.Fatalf("%d lines dropped", n)
+	}
+}
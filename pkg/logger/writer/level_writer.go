@@ -1,94 +1,211 @@
 package writer
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"log"
 	"log/slog"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var _ Writer = &LevelWriter{}
 
-func NewLevelWriter(project, path string, splitTime time.Duration) Writer {
-	w := &LevelWriter{
-		project:   project,
-		path:      strings.TrimSuffix(path, "/") + "/",
-		splitTime: splitTime,
+const (
+	OverflowBlock      = "block"       // 缓冲区满时阻塞写入
+	OverflowDropOldest = "drop_oldest" // 缓冲区满时丢弃最早的日志
+	OverflowDropNewest = "drop_newest" // 缓冲区满时丢弃新写入的日志
+)
+
+// flushInterval 后台写入文件的间隔, 缓冲区超过一半时立即写入
+const flushInterval = 100 * time.Millisecond
+
+// Options 日志文件的切分, 保留及缓冲策略
+type Options struct {
+	SplitTime    time.Duration // 按时间切分的间隔, 单位分钟, 0 不按时间切分
+	MaxSize      int64         // 单个文件的最大字节数, 超过后在同一时间段内切分为 .1.log, .2.log ..., 0 不限制
+	MaxAge       time.Duration // 文件的保留时间, 0 不限制
+	MaxTotalSize int64         // 全部日志文件的最大字节数, 超过后从最早的文件开始删除, 0 不限制
+	Compress     bool          // gzip 压缩已切分的文件
+	Symlink      bool          // 创建指向当前文件的软链接, project.log 或 project.<level>.log
+
+	BufferSize    int           // 缓冲的最大日志条数, 默认 8192
+	Overflow      string        // 缓冲区满时的处理方式, 默认 OverflowBlock
+	FsyncInterval time.Duration // 定时 fsync 的间隔, 0 不主动 fsync
+	Sync          bool          // 同步写入文件, 不使用缓冲, 用于测试
+}
 
-		data:   map[slog.Level][]byte{},
-		exitCh: make(chan struct{}),
-		stopCh: make(chan struct{}),
+func (o *Options) init() {
+	if o.BufferSize <= 0 {
+		o.BufferSize = 8192
 	}
 
-	go w.write()
+	if o.Overflow == "" {
+		o.Overflow = OverflowBlock
+	}
+}
+
+func NewLevelWriter(project, path string, opts Options) Writer {
+	return newLevelWriter(project, path, opts, false)
+}
+
+func newLevelWriter(project, path string, opts Options, ignoreLevel bool) *LevelWriter {
+	opts.init()
+
+	w := &LevelWriter{
+		project:     project,
+		path:        strings.TrimSuffix(path, "/") + "/",
+		opts:        opts,
+		pattern:     regexp.MustCompile(`^` + regexp.QuoteMeta(project) + `\.([a-z]+\.)?\d{12}(\.\d+)?\.log(\.gz)?$`),
+		ignoreLevel: ignoreLevel,
+
+		ring:    make([]entry, opts.BufferSize),
+		batches: map[slog.Level][]byte{},
+		flushCh: make(chan struct{}, 1),
+		exitCh:  make(chan struct{}),
+		stopCh:  make(chan struct{}),
+	}
+
+	w.notFull = sync.NewCond(&w.m)
+
+	// 清理上次运行遗留的过期文件
+	w.background(nil)
+
+	if !opts.Sync {
+		go w.write()
+	} else {
+		close(w.exitCh)
+	}
 
 	return w
 }
 
 type LevelWriter struct {
-	m sync.Mutex
-	w sync.WaitGroup
+	m       sync.Mutex
+	notFull *sync.Cond
+	bg      sync.WaitGroup // 压缩及清理任务
 
 	project     string
 	path        string
-	splitTime   time.Duration
+	opts        Options
+	pattern     *regexp.Regexp // 匹配本项目的日志文件
 	closed      bool
 	ignoreLevel bool
 
-	files  sync.Map
-	data   map[slog.Level][]byte
-	exitCh chan struct{}
-	stopCh chan struct{}
+	// 环形缓冲区, 由 m 保护
+	ring  []entry
+	head  int
+	count int
+
+	dropped atomic.Int64
+	blocked atomic.Int64
+
+	cleanMu sync.Mutex
+	files   sync.Map              // level => *logFile
+	batches map[slog.Level][]byte // 后台写入时按级别合并的日志, 只在写入协程中使用
+	flushCh chan struct{}
+	exitCh  chan struct{}
+	stopCh  chan struct{}
+}
+
+type entry struct {
+	lvl  slog.Level
+	data []byte
+}
+
+type logFile struct {
+	fp    *os.File
+	name  string // 文件名, 不含目录
+	base  string // 不含序号的文件名, eg: project.info.200601021504
+	index int
+	size  int64
 }
 
 func (w *LevelWriter) write() {
-	stop := false
-	for !stop {
-		w.m.Lock()
-		s := w.data
-		w.data = map[slog.Level][]byte{}
-		w.m.Unlock()
+	defer close(w.exitCh)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
 
-		w.writeData(s)
+	lastSync := time.Now()
+
+	for {
+		stop := false
 
 		select {
 		case <-w.stopCh:
 			stop = true
-		default:
-			time.Sleep(time.Millisecond * 100)
+		case <-w.flushCh:
+		case <-ticker.C:
 		}
-	}
 
-	w.writeData(w.data)
+		w.flush()
 
-	close(w.exitCh)
+		if w.opts.FsyncInterval > 0 && time.Since(lastSync) >= w.opts.FsyncInterval {
+			w.fsync()
+			lastSync = time.Now()
+		}
+
+		if stop {
+			return
+		}
+	}
 }
 
-func (w *LevelWriter) writeData(data map[slog.Level][]byte) {
-	for l, v := range data {
+// flush 取出缓冲区中的全部日志, 按级别合并后依次写入
+func (w *LevelWriter) flush() {
+	w.m.Lock()
+	for ; w.count > 0; w.count-- {
+		e := &w.ring[w.head]
+		w.batches[e.lvl] = append(w.batches[e.lvl], e.data...)
+
+		// 超大的日志不复用内存
+		if cap(e.data) > 16<<10 {
+			e.data = nil
+		}
+
+		w.head = (w.head + 1) % len(w.ring)
+	}
+	w.notFull.Broadcast()
+	w.m.Unlock()
+
+	for l, v := range w.batches {
 		if len(v) == 0 {
 			continue
 		}
 
-		w.w.Add(1)
-		go func(l slog.Level, v []byte) {
-			w.writeFile(l, v)
-			w.w.Done()
-		}(l, v)
+		w.writeFile(l, v)
+		w.batches[l] = v[:0]
 	}
 }
 
+func (w *LevelWriter) fsync() {
+	w.files.Range(func(_, v any) bool {
+		if err := v.(*logFile).fp.Sync(); err != nil {
+			log.Println(err.Error())
+		}
+
+		return true
+	})
+}
+
 func (w *LevelWriter) writeFile(lvl slog.Level, data []byte) {
-	h, err := w.handler(lvl)
+	f, err := w.handler(lvl)
 	if err != nil {
 		log.Println(err.Error())
 		return
 	}
 
-	if _, err = h.Write(data); err != nil {
+	n, err := f.fp.Write(data)
+	f.size += int64(n)
+
+	if err != nil {
 		log.Println(err.Error())
 	}
 }
@@ -99,64 +216,320 @@ func (w *LevelWriter) Write(lvl slog.Level, data []byte) error {
 	}
 
 	w.m.Lock()
-	w.data[lvl] = append(w.data[lvl], data...)
-	w.m.Unlock()
+	defer w.m.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	if w.opts.Sync {
+		w.writeFile(lvl, data)
+		return nil
+	}
+
+	for w.count == len(w.ring) {
+		switch w.opts.Overflow {
+		case OverflowDropNewest:
+			w.dropped.Add(1)
+			return nil
+		case OverflowDropOldest:
+			w.head = (w.head + 1) % len(w.ring)
+			w.count--
+			w.dropped.Add(1)
+		default:
+			w.blocked.Add(1)
+			w.notify()
+			w.notFull.Wait()
+
+			if w.closed {
+				return nil
+			}
+		}
+	}
+
+	e := &w.ring[(w.head+w.count)%len(w.ring)]
+	e.lvl = lvl
+	e.data = append(e.data[:0], data...)
+
+	if w.count++; w.count == len(w.ring)/2 {
+		w.notify()
+	}
 
 	return nil
 }
 
-func (w *LevelWriter) handler(lvl slog.Level) (fp *os.File, err error) {
+func (w *LevelWriter) notify() {
+	select {
+	case w.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// Dropped 返回缓冲区满时丢弃的日志条数
+func (w *LevelWriter) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// Blocked 返回缓冲区满时阻塞写入的次数
+func (w *LevelWriter) Blocked() int64 {
+	return w.blocked.Load()
+}
+
+func (w *LevelWriter) handler(lvl slog.Level) (*logFile, error) {
 	now := time.Now().Unix()
-	if w.splitTime > 0 {
-		now -= now % int64(time.Minute*w.splitTime/time.Second)
+	if w.opts.SplitTime > 0 {
+		now -= now % int64(time.Minute*w.opts.SplitTime/time.Second)
 	}
 
 	ls := strings.ToLower(lvl.String())
 
-	format := w.project + ".%s.log" // time.log
-	args := []any{time.Unix(now, 0).Format("200601021504")}
+	base := w.project + "." + time.Unix(now, 0).Format("200601021504") // project.time
 	if !w.ignoreLevel {
-		format = w.project + ".%s.%s.log" // level.time.log
-		args = append([]any{ls}, args...)
+		base = w.project + "." + ls + "." + time.Unix(now, 0).Format("200601021504") // project.level.time
 	}
 
-	file := fmt.Sprintf(format, args...)
+	index := 0
 
 	if tmp, ok := w.files.Load(ls); ok {
-		if fp = tmp.(*os.File); strings.HasSuffix(fp.Name(), file) {
-			return
+		f := tmp.(*logFile)
+
+		if f.base == base {
+			if w.opts.MaxSize <= 0 || f.size < w.opts.MaxSize {
+				return f, nil
+			}
+
+			index = f.index + 1
+		}
+
+		_ = f.fp.Close()
+		w.background(f)
+	}
+
+	return w.open(ls, base, index)
+}
+
+// open 打开 base 对应的文件, 跳过已写满或已压缩的序号
+func (w *LevelWriter) open(ls, base string, index int) (*logFile, error) {
+	f := &logFile{base: base, index: index}
+
+	for {
+		f.name = base + ".log"
+		if f.index > 0 {
+			f.name = fmt.Sprintf("%s.%d.log", base, f.index)
+		}
+
+		if _, err := os.Stat(w.path + f.name + ".gz"); err == nil {
+			f.index++
+			continue
+		}
+
+		info, err := os.Stat(w.path + f.name)
+		if err == nil && w.opts.MaxSize > 0 && info.Size() >= w.opts.MaxSize {
+			f.index++
+			continue
+		}
+
+		if err == nil {
+			f.size = info.Size()
 		}
 
-		_ = fp.Close()
+		break
 	}
 
-	fp, err = os.OpenFile(w.path+file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0777)
+	fp, err := os.OpenFile(w.path+f.name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0777)
 	if err != nil {
+		return nil, err
+	}
+
+	f.fp = fp
+	w.files.Store(ls, f)
+
+	if w.opts.Symlink {
+		link := w.project + ".log"
+		if !w.ignoreLevel {
+			link = w.project + "." + ls + ".log"
+		}
+
+		if err = w.symlink(f.name, link); err != nil {
+			log.Println(err.Error())
+		}
+	}
+
+	return f, nil
+}
+
+// symlink 原子替换软链接, 指向同目录下的 name
+func (w *LevelWriter) symlink(name, link string) error {
+	tmp := w.path + link + ".tmp"
+	_ = os.Remove(tmp)
+
+	if err := os.Symlink(name, tmp); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, w.path+link)
+}
+
+// background 后台压缩已切分的文件并清理过期文件
+func (w *LevelWriter) background(closed *logFile) {
+	compress := closed != nil && w.opts.Compress && closed.size > 0
+	if !compress && w.opts.MaxAge <= 0 && w.opts.MaxTotalSize <= 0 {
 		return
 	}
 
-	w.files.Store(ls, fp)
+	w.bg.Add(1)
+	go func() {
+		defer w.bg.Done()
+
+		w.cleanMu.Lock()
+		defer w.cleanMu.Unlock()
+
+		if compress {
+			if err := compressFile(w.path + closed.name); err != nil {
+				log.Println(err.Error())
+			}
+		}
 
-	return
+		w.cleanup()
+	}()
+}
+
+// cleanup 删除超过保留时间的文件, 总大小超过限制时从最早的文件开始删除, 不删除正在写入的文件
+func (w *LevelWriter) cleanup() {
+	if w.opts.MaxAge <= 0 && w.opts.MaxTotalSize <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(w.path)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	active := map[string]bool{}
+	w.files.Range(func(_, v any) bool {
+		active[v.(*logFile).name] = true
+		return true
+	})
+
+	type item struct {
+		name    string
+		size    int64
+		modTime time.Time
+	}
+
+	var (
+		items []item
+		total int64
+	)
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !w.pattern.MatchString(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		total += info.Size()
+
+		if !active[entry.Name()] {
+			items = append(items, item{entry.Name(), info.Size(), info.ModTime()})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].modTime.Before(items[j].modTime)
+	})
+
+	deadline := time.Now().Add(-w.opts.MaxAge)
+
+	for _, v := range items {
+		expired := w.opts.MaxAge > 0 && v.modTime.Before(deadline)
+		oversize := w.opts.MaxTotalSize > 0 && total > w.opts.MaxTotalSize
+
+		if !expired && !oversize {
+			break
+		}
+
+		if err = os.Remove(w.path + v.name); err != nil {
+			log.Println(err.Error())
+			continue
+		}
+
+		total -= v.size
+	}
+}
+
+// compressFile 压缩为 name.gz 并删除原文件, 保留原文件的修改时间用于计算保留时间
+func compressFile(name string) (err error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(name + ".gz")
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+
+	if _, err = io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+
+	if err = gz.Close(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+
+	if err = dst.Close(); err != nil {
+		return err
+	}
+
+	_ = os.Chtimes(name+".gz", info.ModTime(), info.ModTime())
+
+	return os.Remove(name)
 }
 
 func (w *LevelWriter) WithSplitTime(st time.Duration) {
-	w.splitTime = st
+	w.opts.SplitTime = st
 }
 
+// Close 写入缓冲区中剩余的日志并关闭文件, 之后的日志直接丢弃
 func (w *LevelWriter) Close() {
+	w.m.Lock()
 	if w.closed {
+		w.m.Unlock()
 		return
 	}
 
 	w.closed = true
+	w.notFull.Broadcast()
+	w.m.Unlock()
 
 	close(w.stopCh)
 	<-w.exitCh
-	w.w.Wait()
+	w.bg.Wait()
 
 	w.files.Range(func(_, v any) bool {
-		v.(*os.File).Close()
+		v.(*logFile).fp.Close()
 		return true
 	})
 }
@@ -0,0 +1,90 @@
+package writer
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLevelWriterRotate(t *testing.T) {
+	dir := t.TempDir()
+
+	// 过期文件及其他项目的文件
+	old := filepath.Join(dir, "test.202001010000.log")
+	other := filepath.Join(dir, "other.202001010000.log")
+	for _, name := range []string{old, other} {
+		_ = os.WriteFile(name, []byte("old\n"), 0644)
+		_ = os.Chtimes(name, time.Now().Add(-48*time.Hour), time.Now().Add(-48*time.Hour))
+	}
+
+	w := NewFileWriter("test", dir, Options{
+		SplitTime: 60,
+		MaxSize:   100,
+		MaxAge:    24 * time.Hour,
+		Compress:  true,
+		Symlink:   true,
+		Sync:      true,
+	})
+
+	line := []byte(strings.Repeat("x", 79) + "\n")
+	for i := 0; i < 3; i++ {
+		_ = w.Write(slog.LevelInfo, line)
+		_ = w.Write(slog.LevelError, line)
+	}
+
+	w.Close()
+
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Fatal("expired file not removed")
+	}
+
+	if _, err := os.Stat(other); err != nil {
+		t.Fatal("file of other project removed")
+	}
+
+	var plain, gz int
+	files, _ := filepath.Glob(filepath.Join(dir, "test.2*"))
+	for _, name := range files {
+		if strings.HasSuffix(name, ".gz") {
+			gz++
+		} else {
+			plain++
+		}
+	}
+
+	if plain != 1 || gz != 2 {
+		t.Fatalf("unexpected files %v", files)
+	}
+
+	target, err := os.Readlink(filepath.Join(dir, "test.log"))
+	if err != nil || !strings.HasSuffix(target, ".2.log") {
+		t.Fatalf("unexpected symlink %q, %v", target, err)
+	}
+}
+
+func TestLevelWriterBuffer(t *testing.T) {
+	dir := t.TempDir()
+
+	w := NewLevelWriter("test", dir, Options{BufferSize: 4, Overflow: OverflowDropNewest}).(*LevelWriter)
+
+	for i := 0; i < 1000; i++ {
+		_ = w.Write(slog.LevelInfo, []byte("info\n"))
+		_ = w.Write(slog.LevelWarn, []byte("warn\n"))
+	}
+
+	w.Close()
+
+	var lines int
+	files, _ := filepath.Glob(filepath.Join(dir, "test.*.log"))
+	for _, name := range files {
+		data, _ := os.ReadFile(name)
+		lines += strings.Count(string(data), "\n")
+	}
+
+	if int64(lines)+w.Dropped() != 2000 {
+		t.Fatalf("%d lines written, %d dropped", lines, w.Dropped())
+	}
+}
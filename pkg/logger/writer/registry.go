@@ -0,0 +1,119 @@
+package writer
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Config 创建 Writer 的配置, 各类型只使用其中的部分字段
+type Config struct {
+	Project string
+	Path    string  // file: 日志目录
+	Options Options // file: 切分及保留策略
+
+	Network string // syslog: unixgram, unix
+	Address string // syslog: socket 路径; http: 接收日志的 URL
+	Tag     string // syslog
+
+	Headers       map[string]string // http
+	BatchSize     int               // http
+	FlushInterval time.Duration     // http
+	Timeout       time.Duration     // http
+	MaxRetries    int               // http
+
+	Extra map[string]any // 自定义 Writer 的配置
+}
+
+// Factory 根据配置创建 Writer
+type Factory func(cfg Config) (Writer, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+func init() {
+	Register("file", func(cfg Config) (Writer, error) {
+		return NewFileWriter(cfg.Project, cfg.Path, cfg.Options), nil
+	})
+
+	Register("stdout", func(Config) (Writer, error) {
+		return NewConsoleWriter(), nil
+	})
+
+	Register("stderr", func(Config) (Writer, error) {
+		return NewStderrWriter(), nil
+	})
+
+	Register("syslog", func(cfg Config) (Writer, error) {
+		tag := cfg.Tag
+		if tag == "" {
+			tag = cfg.Project
+		}
+
+		return NewSyslogWriter(SyslogOptions{Network: cfg.Network, Address: cfg.Address, Tag: tag})
+	})
+
+	Register("http", func(cfg Config) (Writer, error) {
+		return NewHTTPWriter(HTTPOptions{
+			URL:           cfg.Address,
+			Headers:       cfg.Headers,
+			BatchSize:     cfg.BatchSize,
+			FlushInterval: cfg.FlushInterval,
+			Timeout:       cfg.Timeout,
+			MaxRetries:    cfg.MaxRetries,
+		})
+	})
+}
+
+// Register 注册 Writer 类型, 同名时覆盖
+func Register(name string, factory Factory) {
+	if name == "" || factory == nil {
+		panic("writer: invalid register")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[name] = factory
+}
+
+// Registered 返回类型是否已注册
+func Registered(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	_, ok := registry[name]
+
+	return ok
+}
+
+// Types 返回已注册的类型
+func Types() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// New 创建 name 类型的 Writer
+func New(name string, cfg Config) (Writer, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("日志 writer 类型 %s 未注册", name)
+	}
+
+	return factory(cfg)
+}
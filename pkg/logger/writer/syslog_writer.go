@@ -0,0 +1,131 @@
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+var _ Writer = &SyslogWriter{}
+
+// 未指定地址时依次尝试的 socket
+var syslogAddresses = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+type SyslogOptions struct {
+	Network  string // unixgram, unix, 默认依次尝试
+	Address  string // socket 路径, 默认依次尝试 /dev/log, /var/run/syslog, /var/run/log
+	Tag      string // 默认程序名
+	Facility int    // 默认 LOG_LOCAL0
+}
+
+// SyslogWriter 通过本机 unix socket 写入 syslog
+type SyslogWriter struct {
+	mu   sync.Mutex
+	opts SyslogOptions
+	conn net.Conn
+}
+
+func NewSyslogWriter(opts SyslogOptions) (*SyslogWriter, error) {
+	if opts.Tag == "" {
+		opts.Tag = os.Args[0]
+	}
+
+	if opts.Facility == 0 {
+		opts.Facility = 16 // LOG_LOCAL0
+	}
+
+	w := &SyslogWriter{opts: opts}
+
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *SyslogWriter) connect() error {
+	networks := []string{"unixgram", "unix"}
+	if w.opts.Network != "" {
+		networks = []string{w.opts.Network}
+	}
+
+	addresses := syslogAddresses
+	if w.opts.Address != "" {
+		addresses = []string{w.opts.Address}
+	}
+
+	for _, network := range networks {
+		for _, address := range addresses {
+			conn, err := net.Dial(network, address)
+			if err == nil {
+				w.conn = conn
+				return nil
+			}
+		}
+	}
+
+	return errors.New("连接 syslog 失败")
+}
+
+func (w *SyslogWriter) Write(lvl slog.Level, data []byte) error {
+	data = bytes.TrimRight(data, "\n")
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var err error
+	for i := 0; i < 2; i++ {
+		if w.conn == nil {
+			if err = w.connect(); err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(
+			w.conn,
+			"<%d>%s %s[%d]: %s\n",
+			w.opts.Facility<<3|severity(lvl),
+			time.Now().Format(time.Stamp),
+			w.opts.Tag,
+			os.Getpid(),
+			data,
+		)
+		if err == nil {
+			return nil
+		}
+
+		// syslog 重启后重新连接
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	return err
+}
+
+func (w *SyslogWriter) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+}
+
+func severity(lvl slog.Level) int {
+	switch {
+	case lvl < slog.LevelInfo:
+		return 7 // LOG_DEBUG
+	case lvl < slog.LevelWarn:
+		return 6 // LOG_INFO
+	case lvl < slog.LevelError:
+		return 4 // LOG_WARNING
+	default:
+		return 3 // LOG_ERR
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func BodyLimit(maxSize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if maxSize <= 0 || c.Request.Body == nil {
+			return
+		}
+
+		if c.Request.ContentLength > maxSize {
+			AbortWithStatus(c, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+
+		// Content-Length 未知 (chunked) 时, 读取超出限制会返回 *http.MaxBytesError
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBodyLimit(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	reached := false
+
+	r := gin.New()
+	r.Use(BodyLimit(8))
+	r.POST("/", func(c *gin.Context) {
+		reached = true
+
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				c.String(http.StatusRequestEntityTooLarge, "too large")
+				return
+			}
+		}
+
+		c.String(200, "ok")
+	})
+
+	// Content-Length 超出限制时直接返回 413
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader(strings.Repeat("a", 9))))
+
+	if w.Code != http.StatusRequestEntityTooLarge || reached {
+		t.Fatalf("expected 413 before handler, got %d", w.Code)
+	}
+
+	// Content-Length 未知时由 MaxBytesReader 限制读取
+	req := httptest.NewRequest("POST", "/", io.MultiReader(strings.NewReader(strings.Repeat("a", 9))))
+	req.ContentLength = -1
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge || !reached {
+		t.Fatalf("expected 413 from handler, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader("ok")))
+
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
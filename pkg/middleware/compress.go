@@ -0,0 +1,292 @@
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var defaultCompressExcludedTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"text/event-stream",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/octet-stream",
+	"application/pdf",
+	"application/wasm",
+}
+
+type CompressOptions struct {
+	Level         int      // 压缩级别, 默认 flate.DefaultCompression
+	MinSize       int      // 小于该长度的响应不压缩, 默认 1024 byte
+	ExcludedTypes []string // 不压缩的 Content-Type 前缀, 默认为常见的已压缩类型
+}
+
+func Compress(opts CompressOptions) gin.HandlerFunc {
+	if opts.Level < flate.HuffmanOnly || opts.Level > flate.BestCompression || opts.Level == flate.NoCompression {
+		opts.Level = flate.DefaultCompression
+	}
+
+	if opts.MinSize <= 0 {
+		opts.MinSize = 1024
+	}
+
+	if opts.ExcludedTypes == nil {
+		opts.ExcludedTypes = defaultCompressExcludedTypes
+	}
+
+	pools := map[string]*sync.Pool{
+		encodingGzip: {New: func() any {
+			w, _ := gzip.NewWriterLevel(io.Discard, opts.Level)
+			return w
+		}},
+		encodingDeflate: {New: func() any {
+			w, _ := flate.NewWriter(io.Discard, opts.Level)
+			return w
+		}},
+	}
+
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.Request.Header.Get("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead || c.Request.Header.Get("Upgrade") != "" {
+			return
+		}
+
+		w := &compressWriter{
+			ResponseWriter: c.Writer,
+			opts:           &opts,
+			pool:           pools[encoding],
+			encoding:       encoding,
+		}
+
+		c.Writer = w
+		defer func() {
+			w.close()
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding 按 Accept-Encoding 的 q 值选择 gzip 或 deflate, 相同时优先 gzip
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	qs := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+
+		qs[strings.ToLower(strings.TrimSpace(name))] = q
+	}
+
+	quality := func(name string) float64 {
+		if q, ok := qs[name]; ok {
+			return q
+		}
+
+		return qs["*"]
+	}
+
+	gq, dq := quality(encodingGzip), quality(encodingDeflate)
+
+	switch {
+	case gq > 0 && gq >= dq:
+		return encodingGzip
+	case dq > 0:
+		return encodingDeflate
+	default:
+		return ""
+	}
+}
+
+type compressor interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+	Flush() error
+}
+
+// compressWriter 在响应达到 MinSize 之前先缓冲, 之后再决定是否压缩,
+// 底层 ResponseWriter 的 Size() 始终为实际写出的 (压缩后) 字节数
+type compressWriter struct {
+	gin.ResponseWriter
+
+	opts     *CompressOptions
+	pool     *sync.Pool
+	encoding string
+
+	buf     []byte
+	decided bool
+	cw      compressor
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		return w.write(data)
+	}
+
+	if !w.bodyAllowed() || !w.compressible() {
+		w.decide(false)
+		return w.write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+	if len(w.buf) < w.opts.MinSize {
+		return len(data), nil
+	}
+
+	w.decide(true)
+	if err := w.flushBuffer(); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Written() bool {
+	return w.decided && w.ResponseWriter.Written()
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		w.decide(w.bodyAllowed() && w.compressible())
+	}
+
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(len(w.buf) > 0 && w.bodyAllowed() && w.compressible())
+		_ = w.flushBuffer()
+	}
+
+	if w.cw != nil {
+		_ = w.cw.Flush()
+	}
+
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) write(data []byte) (int, error) {
+	if w.cw != nil {
+		return w.cw.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressWriter) bodyAllowed() bool {
+	status := w.ResponseWriter.Status()
+
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+func (w *compressWriter) compressible() bool {
+	h := w.Header()
+
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	if cl, err := strconv.Atoi(h.Get("Content-Length")); err == nil && cl < w.opts.MinSize {
+		return false
+	}
+
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+
+	ct = strings.ToLower(ct)
+	for _, v := range w.opts.ExcludedTypes {
+		if strings.HasPrefix(ct, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+
+	if !compress {
+		return
+	}
+
+	h := w.Header()
+
+	// 压缩后无法再从内容推断类型, 先基于原始数据识别
+	if h.Get("Content-Type") == "" && len(w.buf) > 0 {
+		h.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+
+	h.Set("Content-Encoding", w.encoding)
+	h.Add("Vary", "Accept-Encoding")
+	h.Del("Content-Length")
+
+	w.cw = w.pool.Get().(compressor)
+	w.cw.Reset(w.ResponseWriter)
+}
+
+func (w *compressWriter) flushBuffer() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	buf := w.buf
+	w.buf = nil
+
+	_, err := w.write(buf)
+
+	return err
+}
+
+func (w *compressWriter) close() {
+	// 未达到 MinSize 的响应原样输出
+	if !w.decided {
+		w.decide(false)
+	}
+
+	_ = w.flushBuffer()
+
+	if w.cw == nil {
+		return
+	}
+
+	_ = w.cw.Close()
+	w.cw.Reset(io.Discard)
+	w.pool.Put(w.cw)
+	w.cw = nil
+}
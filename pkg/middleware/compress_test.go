@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCompress(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	size := 0
+	body := strings.Repeat("rain ", 1000)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Next()
+		size = c.Writer.Size()
+	}, Compress(CompressOptions{}))
+	r.GET("/large", func(c *gin.Context) { c.String(200, body) })
+	r.GET("/small", func(c *gin.Context) { c.String(200, "ok") })
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/large", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	r.ServeHTTP(w, req)
+
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip encoding, got %q", w.Header().Get("Content-Encoding"))
+	}
+
+	if size != w.Body.Len() {
+		t.Fatalf("writer size %d != body length %d", size, w.Body.Len())
+	}
+
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data, _ := io.ReadAll(zr); string(data) != body {
+		t.Fatal("decompressed body mismatch")
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/small", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	r.ServeHTTP(w, req)
+
+	if w.Header().Get("Content-Encoding") != "" || w.Body.String() != "ok" || size != 2 {
+		t.Fatalf("small response should not be compressed, size %d", size)
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := map[string]string{
+		"":                    "",
+		"gzip, deflate":       "gzip",
+		"deflate, gzip;q=0.5": "deflate",
+		"gzip;q=0, *":         "deflate",
+		"*;q=0":               "",
+		"br":                  "",
+	}
+
+	for header, expected := range cases {
+		if res := negotiateEncoding(header); res != expected {
+			t.Errorf("%q: expected %q, got %q", header, expected, res)
+		}
+	}
+}
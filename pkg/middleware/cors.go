@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CORSOptions struct {
+	AllowOrigins     []string // 允许的来源, 支持 "*" 及 "https://*.example.com"
+	AllowMethods     []string // 默认 GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS
+	AllowHeaders     []string // 为空时回显请求的 Access-Control-Request-Headers
+	ExposeHeaders    []string
+	AllowCredentials bool          // AllowOrigins 为 "*" 时不生效, 需列出具体的来源
+	MaxAge           time.Duration // 预检请求缓存时间
+}
+
+func CORS(opts CORSOptions) gin.HandlerFunc {
+	if len(opts.AllowMethods) == 0 {
+		opts.AllowMethods = []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+			http.MethodHead,
+			http.MethodOptions,
+		}
+	}
+
+	allowAll := false
+	for _, v := range opts.AllowOrigins {
+		if v == "*" {
+			allowAll = true
+		}
+	}
+
+	allowMethods := strings.Join(opts.AllowMethods, ", ")
+	allowHeaders := strings.Join(opts.AllowHeaders, ", ")
+	exposeHeaders := strings.Join(opts.ExposeHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge / time.Second))
+
+	return func(c *gin.Context) {
+		origin := c.Request.Header.Get("Origin")
+		if origin == "" {
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions &&
+			c.Request.Header.Get("Access-Control-Request-Method") != ""
+
+		if !allowAll && !matchOrigin(opts.AllowOrigins, origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+			}
+
+			return
+		}
+
+		h := c.Writer.Header()
+
+		// 规范不允许 "*" 携带凭证, 也不能回显任意来源并允许凭证
+		if allowAll {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+
+			if opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+		}
+
+		if !preflight {
+			if exposeHeaders != "" {
+				h.Set("Access-Control-Expose-Headers", exposeHeaders)
+			}
+
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", allowMethods)
+
+		if allowHeaders != "" {
+			h.Set("Access-Control-Allow-Headers", allowHeaders)
+		} else if reqHeaders := c.Request.Header.Get("Access-Control-Request-Headers"); reqHeaders != "" {
+			h.Set("Access-Control-Allow-Headers", reqHeaders)
+		}
+
+		if opts.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", maxAge)
+		}
+
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+func matchOrigin(allowOrigins []string, origin string) bool {
+	for _, v := range allowOrigins {
+		if strings.EqualFold(v, origin) {
+			return true
+		}
+
+		// https://*.example.com, * 需匹配非空的子域名
+		if i := strings.Index(v, "*"); i >= 0 {
+			prefix, suffix := strings.ToLower(v[:i]), strings.ToLower(v[i+1:])
+			lower := strings.ToLower(origin)
+
+			if len(lower) > len(prefix)+len(suffix) &&
+				strings.HasPrefix(lower, prefix) &&
+				strings.HasSuffix(lower, suffix) &&
+				validSubdomain(lower[len(prefix):len(lower)-len(suffix)]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func validSubdomain(s string) bool {
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || strings.ContainsAny(label, "/:@?#") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	newRouter := func(opts CORSOptions) *gin.Engine {
+		r := gin.New()
+		r.Use(CORS(opts))
+		r.Any("/", func(c *gin.Context) { c.String(200, "ok") })
+
+		return r
+	}
+
+	do := func(r *gin.Engine, method, origin string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/", nil)
+		req.Header.Set("Origin", origin)
+
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", "PUT")
+			req.Header.Set("Access-Control-Request-Headers", "X-Token")
+		}
+
+		r.ServeHTTP(w, req)
+
+		return w
+	}
+
+	r := newRouter(CORSOptions{
+		AllowOrigins:     []string{"https://app.example.com", "https://*.example.org"},
+		ExposeHeaders:    []string{"X-Request-Id"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	})
+
+	// 预检请求
+	w := do(r, http.MethodOptions, "https://app.example.com")
+	h := w.Header()
+
+	if w.Code != http.StatusNoContent || h.Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+		h.Get("Access-Control-Allow-Headers") != "X-Token" || h.Get("Access-Control-Max-Age") != "3600" ||
+		h.Get("Access-Control-Allow-Credentials") != "true" || h.Get("Access-Control-Allow-Methods") == "" {
+		t.Fatalf("unexpected preflight response %d %v", w.Code, h)
+	}
+
+	// 简单请求
+	w = do(r, http.MethodGet, "https://a.b.example.org")
+	if w.Code != 200 || w.Header().Get("Access-Control-Allow-Origin") != "https://a.b.example.org" ||
+		w.Header().Get("Access-Control-Expose-Headers") != "X-Request-Id" {
+		t.Fatalf("unexpected response %d %v", w.Code, w.Header())
+	}
+
+	// 不在允许列表中的来源
+	for _, origin := range []string{"https://evil.com", "https://.example.org", "https://example.org", "https://evil.com/.example.org"} {
+		if w = do(r, http.MethodOptions, origin); w.Code != http.StatusForbidden {
+			t.Fatalf("%s: expected 403, got %d", origin, w.Code)
+		}
+
+		if w = do(r, http.MethodGet, origin); w.Header().Get("Access-Control-Allow-Origin") != "" {
+			t.Fatalf("%s: unexpected allow origin", origin)
+		}
+	}
+
+	// "*" 不允许携带凭证
+	r = newRouter(CORSOptions{AllowOrigins: []string{"*"}, AllowCredentials: true})
+
+	w = do(r, http.MethodGet, "https://any.com")
+	if w.Header().Get("Access-Control-Allow-Origin") != "*" || w.Header().Get("Access-Control-Allow-Credentials") != "" {
+		t.Fatalf("unexpected wildcard response %v", w.Header())
+	}
+}
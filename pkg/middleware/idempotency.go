@@ -0,0 +1,262 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	idempotencyProcessing = "processing"
+	idempotencyDone       = "done"
+
+	// handler 执行后写入结果的超时时间, 客户端断开连接时仍需写入
+	idempotencyWriteTimeout = 3 * time.Second
+)
+
+// 重放时不返回的响应头
+var idempotencySkipHeaders = []string{"Content-Length", "Content-Encoding", "Date", "Set-Cookie", "Vary"}
+
+type IdempotencyOptions struct {
+	Client          redis.UniversalClient
+	Header          string                      // 默认 Idempotency-Key
+	Methods         []string                    // 默认 POST, PATCH
+	Expire          time.Duration               // 响应缓存时间, 默认 24h
+	LockTTL         time.Duration               // 请求处理中标记的最长时间, 默认 1 分钟
+	Prefix          string                      // 默认 idempotency:
+	MaxResponseSize int                         // 超过该长度的响应不缓存, 默认 1MB
+	MaxBodySize     int64                       // 计算摘要时最多读取的请求体长度, 超过时返回 413, 默认 1MB
+	Scope           func(c *gin.Context) string // key 的作用域, 如用户 ID, 默认不区分
+}
+
+func (o *IdempotencyOptions) init() {
+	if o.Header == "" {
+		o.Header = "Idempotency-Key"
+	}
+
+	if len(o.Methods) == 0 {
+		o.Methods = []string{http.MethodPost, http.MethodPatch}
+	}
+
+	if o.Expire <= 0 {
+		o.Expire = 24 * time.Hour
+	}
+
+	if o.LockTTL <= 0 {
+		o.LockTTL = time.Minute
+	}
+
+	if o.Prefix == "" {
+		o.Prefix = "idempotency:"
+	}
+
+	if o.MaxResponseSize <= 0 {
+		o.MaxResponseSize = 1 << 20
+	}
+
+	if o.MaxBodySize <= 0 {
+		o.MaxBodySize = 1 << 20
+	}
+}
+
+type idempotencyRecord struct {
+	Status      string      `json:"status"`
+	Fingerprint string      `json:"fp"`
+	Code        int         `json:"code,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// Idempotency 对携带 Idempotency-Key 的请求去重: 首次请求正常处理并缓存响应, 重复请求直接返回缓存的响应,
+// 首次请求处理中时返回 409, 相同 key 对应不同的请求内容时返回 422, 5xx 响应不缓存, 客户端可重试
+func Idempotency(opts IdempotencyOptions) gin.HandlerFunc {
+	opts.init()
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(opts.Header)
+		if key == "" || opts.Client == nil || !slices.Contains(opts.Methods, c.Request.Method) {
+			return
+		}
+
+		if opts.Scope != nil {
+			key = opts.Scope(c) + ":" + key
+		}
+		key = opts.Prefix + key
+
+		fp, err := fingerprint(c, opts.MaxBodySize)
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				AbortWithStatus(c, http.StatusRequestEntityTooLarge, "request body too large")
+				return
+			}
+
+			AbortWithStatus(c, http.StatusBadRequest, "invalid request body")
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		data, _ := json.Marshal(idempotencyRecord{Status: idempotencyProcessing, Fingerprint: fp})
+
+		ok, err := opts.Client.SetNX(ctx, key, data, opts.LockTTL).Result()
+		if err != nil {
+			// 存储异常时放行
+			slog.Error("idempotency", slog.String("key", key), slog.String("error", err.Error()))
+			return
+		}
+
+		if !ok {
+			opts.replay(c, key, fp)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: c.Writer, max: opts.MaxResponseSize}
+		c.Writer = rec
+
+		// handler 执行后客户端可能已断开, 请求的 context 被取消, 写入结果使用独立的 context
+		wctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencyWriteTimeout)
+
+		completed := false
+		defer func() {
+			c.Writer = rec.ResponseWriter
+
+			// handler panic 时删除处理中标记, 允许客户端重试
+			if !completed {
+				opts.Client.Del(wctx, key)
+			}
+
+			cancel()
+		}()
+
+		c.Next()
+
+		completed = true
+
+		if rec.Status() >= http.StatusInternalServerError || rec.overflow {
+			opts.Client.Del(wctx, key)
+			return
+		}
+
+		header := http.Header{}
+		for k, v := range rec.Header() {
+			if !slices.Contains(idempotencySkipHeaders, k) {
+				header[k] = v
+			}
+		}
+
+		data, _ = json.Marshal(idempotencyRecord{
+			Status:      idempotencyDone,
+			Fingerprint: fp,
+			Code:        rec.Status(),
+			Header:      header,
+			Body:        rec.body,
+		})
+
+		if err = opts.Client.Set(wctx, key, data, opts.Expire).Err(); err != nil {
+			slog.Error("idempotency", slog.String("key", key), slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (o *IdempotencyOptions) replay(c *gin.Context, key, fp string) {
+	data, err := o.Client.Get(c.Request.Context(), key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// 首次请求刚好处理失败或过期
+		AbortWithStatus(c, http.StatusConflict, "request is being processed")
+		return
+	}
+
+	if err != nil {
+		slog.Error("idempotency", slog.String("key", key), slog.String("error", err.Error()))
+		AbortWithStatus(c, http.StatusServiceUnavailable, "service unavailable")
+		return
+	}
+
+	var record idempotencyRecord
+	if err = json.Unmarshal(data, &record); err != nil {
+		AbortWithStatus(c, http.StatusConflict, "request is being processed")
+		return
+	}
+
+	if record.Fingerprint != fp {
+		AbortWithStatus(c, http.StatusUnprocessableEntity, "idempotency key reused with a different request")
+		return
+	}
+
+	if record.Status != idempotencyDone {
+		AbortWithStatus(c, http.StatusConflict, "request is being processed")
+		return
+	}
+
+	for k, v := range record.Header {
+		c.Writer.Header()[k] = v
+	}
+	c.Header("Idempotent-Replayed", "true")
+
+	c.Status(record.Code)
+	_, _ = c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+// fingerprint 计算请求方法, 路径, 查询参数及请求体的摘要, 读取后放回请求体,
+// 请求体超过 maxSize 时返回 *http.MaxBytesError
+func fingerprint(c *gin.Context, maxSize int64) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "?" + c.Request.URL.RawQuery + "\n"))
+
+	if c.Request.Body != nil {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSize))
+		if err != nil {
+			return "", err
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		h.Write(body)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	max      int
+	body     []byte
+	overflow bool
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *responseRecorder) capture(data []byte) {
+	if w.overflow {
+		return
+	}
+
+	if len(w.body)+len(data) > w.max {
+		w.overflow = true
+		w.body = nil
+
+		return
+	}
+
+	w.body = append(w.body, data...)
+}
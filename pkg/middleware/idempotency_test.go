@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+type cancelKey struct{}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer client.Close()
+
+	var calls atomic.Int64
+	block := make(chan struct{})
+
+	r := gin.New()
+	r.Use(Idempotency(IdempotencyOptions{Client: client, MaxBodySize: 64}))
+	r.POST("/orders", func(c *gin.Context) {
+		calls.Add(1)
+		c.JSON(http.StatusCreated, gin.H{"id": calls.Load()})
+	})
+	r.POST("/disconnect", func(c *gin.Context) {
+		// 模拟 handler 执行后客户端断开连接
+		c.Request.Context().Value(cancelKey{}).(context.CancelFunc)()
+		c.String(http.StatusCreated, "created")
+	})
+	r.POST("/slow", func(c *gin.Context) {
+		<-block
+		c.String(http.StatusOK, "ok")
+	})
+
+	do := func(path, key, body string) *httptest.ResponseRecorder {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req = req.WithContext(context.WithValue(ctx, cancelKey{}, cancel))
+		req.Header.Set("Idempotency-Key", key)
+		r.ServeHTTP(w, req)
+
+		return w
+	}
+
+	first := do("/orders", "k1", `{"n":1}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("unexpected status %d", first.Code)
+	}
+
+	replay := do("/orders", "k1", `{"n":1}`)
+	if replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() ||
+		replay.Header().Get("Idempotent-Replayed") != "true" || calls.Load() != 1 {
+		t.Fatalf("unexpected replay %d %q %v", replay.Code, replay.Body.String(), replay.Header())
+	}
+
+	if w := do("/orders", "k1", `{"n":2}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", w.Code)
+	}
+
+	if w := do("/orders", "k3", strings.Repeat("a", 65)); w.Code != http.StatusRequestEntityTooLarge || s.Exists("idempotency:k3") {
+		t.Fatalf("expected 413, got %d", w.Code)
+	}
+
+	// 客户端断开后仍保存结果, 重试时返回缓存的响应
+	if w := do("/disconnect", "k4", ""); w.Code != http.StatusCreated {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+
+	if w := do("/disconnect", "k4", ""); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected replay after disconnect, got %d", w.Code)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		do("/slow", "k2", "")
+		close(done)
+	}()
+
+	// 等待首个请求写入处理中标记
+	for !s.Exists("idempotency:k2") {
+		time.Sleep(time.Millisecond)
+	}
+
+	if w := do("/slow", "k2", ""); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", w.Code)
+	}
+
+	close(block)
+	<-done
+}
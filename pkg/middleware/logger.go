@@ -1,22 +1,110 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"log/slog"
 	"math"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+var defaultRedactFields = []string{"password", "passwd", "secret", "token", "authorization", "cookie"}
+
+type LoggerOptions struct {
+	IgnorePaths []string // 不记录的路径, 支持 path.Match 及 "/static/*" 前缀匹配, 默认 /health
+	Headers     []string // 额外记录的请求头
+	Query       bool     // 记录查询字符串
+	UserAgent   bool     // 记录 User-Agent
+	Route       bool     // uri 使用路由模板 (如 /user/:id) 替代原始路径
+
+	// 每秒 2xx 日志数超过 SampleThreshold 后, 每 SampleRate 条记录 1 条, SampleRate <= 1 时不采样
+	SampleThreshold int
+	SampleRate      int
+
+	RequestBody  bool     // 记录请求体 (仅 json, form, text, xml)
+	ResponseBody bool     // 记录响应体 (仅未压缩的 json, text, xml)
+	MaxBodySize  int      // 请求体/响应体最大记录长度, 默认 4096 byte
+	RedactFields []string // 请求头, 查询参数及 json/form 字段名包含这些关键字时替换为 *, 默认 password, secret, token 等
+
+	redactText []redactPattern // 无法解析的请求体/响应体按文本替换敏感字段的值
+}
+
+func (o *LoggerOptions) init() {
+	if o.IgnorePaths == nil {
+		o.IgnorePaths = []string{"/health"}
+	}
+
+	if o.MaxBodySize <= 0 {
+		o.MaxBodySize = 4096
+	}
+
+	if o.RedactFields == nil {
+		o.RedactFields = defaultRedactFields
+	}
+
+	fields := make([]string, len(o.RedactFields))
+	for i, v := range o.RedactFields {
+		fields[i] = strings.ToLower(v)
+	}
+	o.RedactFields = fields
+
+	quoted := make([]string, len(fields))
+	for i, v := range fields {
+		quoted[i] = regexp.QuoteMeta(v)
+	}
+
+	key := `[\w.-]*(?:` + strings.Join(quoted, "|") + `)[\w.-]*`
+	o.redactText = []redactPattern{
+		// "password": "xxx", 截断的字符串没有结尾的引号
+		{regexp.MustCompile(`(?i)("` + key + `"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]*)`), `${1}"*"`},
+		// <password>xxx</password>
+		{regexp.MustCompile(`(?i)(<` + key + `(?:\s[^>]*)?>)([^<]*)`), `${1}*`},
+		// password=xxx, password: xxx
+		{regexp.MustCompile(`(?i)(\b` + key + `\s*[=:]\s*)([^&\s,;"'<]*)`), `${1}*`},
+	}
+}
+
 func Logger() gin.HandlerFunc {
+	return LoggerWithOptions(LoggerOptions{})
+}
+
+func LoggerWithOptions(opts LoggerOptions) gin.HandlerFunc {
+	opts.init()
+
+	sampler := &logSampler{threshold: int64(opts.SampleThreshold), rate: int64(opts.SampleRate)}
+
 	return func(c *gin.Context) {
 		uri := c.Request.URL.Path
 		sTime := time.Now()
 
+		if opts.ignore(uri) {
+			c.Next()
+			return
+		}
+
+		var reqBody []byte
+		if opts.RequestBody && loggableContentType(c.ContentType()) {
+			reqBody = opts.readRequestBody(c)
+		}
+
+		var respBody *bodyLogWriter
+		if opts.ResponseBody {
+			respBody = &bodyLogWriter{ResponseWriter: c.Writer, max: opts.MaxBodySize}
+			c.Writer = respBody
+		}
+
 		c.Next()
 
-		if uri == "/health" {
-			return
+		if respBody != nil {
+			c.Writer = respBody.ResponseWriter
 		}
 
 		eTime := time.Now()
@@ -27,6 +115,14 @@ func Logger() gin.HandlerFunc {
 			dataLength = 0
 		}
 
+		if len(c.Errors) == 0 && httpCode < 300 && !sampler.allow(eTime.Unix()) {
+			return
+		}
+
+		if opts.Route && c.FullPath() != "" {
+			uri = c.FullPath()
+		}
+
 		fields := []any{
 			slog.Int("dataLength", dataLength),
 			slog.Int64("receiveTime", sTime.UnixNano()/int64(time.Millisecond)),
@@ -38,8 +134,10 @@ func Logger() gin.HandlerFunc {
 			slog.String("clientIP", c.ClientIP()),
 		}
 
+		fields = append(fields, opts.extraFields(c, reqBody, respBody)...)
+
 		if len(c.Errors) > 0 {
-			slog.Error(c.Errors.ByType(gin.ErrorTypePrivate).String(), fields)
+			slog.Error(c.Errors.ByType(gin.ErrorTypePrivate).String(), fields...)
 		} else {
 			switch {
 			case httpCode > 499:
@@ -52,3 +150,220 @@ func Logger() gin.HandlerFunc {
 		}
 	}
 }
+
+func (o *LoggerOptions) ignore(uri string) bool {
+	for _, v := range o.IgnorePaths {
+		if v == uri {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(v, "*"); ok && strings.HasPrefix(uri, prefix) {
+			return true
+		}
+
+		if ok, _ := path.Match(v, uri); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *LoggerOptions) extraFields(c *gin.Context, reqBody []byte, respBody *bodyLogWriter) []any {
+	var fields []any
+
+	if o.Query && c.Request.URL.RawQuery != "" {
+		fields = append(fields, slog.String("query", o.redactQuery(c.Request.URL.RawQuery)))
+	}
+
+	if o.UserAgent {
+		fields = append(fields, slog.String("userAgent", c.Request.UserAgent()))
+	}
+
+	if len(o.Headers) > 0 {
+		headers := map[string]string{}
+		for _, v := range o.Headers {
+			if hv := c.Request.Header.Get(v); hv != "" {
+				headers[v] = o.redactValue(v, hv)
+			}
+		}
+
+		fields = append(fields, slog.Any("headers", headers))
+	}
+
+	if len(reqBody) > 0 {
+		fields = append(fields, slog.String("requestBody", o.redactBody(c.ContentType(), reqBody)))
+	}
+
+	if respBody != nil && len(respBody.body) > 0 && c.Writer.Header().Get("Content-Encoding") == "" {
+		ct := c.Writer.Header().Get("Content-Type")
+		if ct, _, _ = strings.Cut(ct, ";"); loggableContentType(ct) {
+			fields = append(fields, slog.String("responseBody", o.redactBody(ct, respBody.body)))
+		}
+	}
+
+	return fields
+}
+
+// readRequestBody 读取最多 MaxBodySize 字节并放回请求体, 不影响后续 handler 读取
+func (o *LoggerOptions) readRequestBody(c *gin.Context) []byte {
+	if c.Request.Body == nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, int64(o.MaxBodySize)))
+	if err != nil {
+		return nil
+	}
+
+	c.Request.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(data), c.Request.Body),
+		Closer: c.Request.Body,
+	}
+
+	return data
+}
+
+func (o *LoggerOptions) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, v := range o.RedactFields {
+		if strings.Contains(key, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *LoggerOptions) redactValue(key, value string) string {
+	if o.sensitive(key) {
+		return "*"
+	}
+
+	return value
+}
+
+func (o *LoggerOptions) redactQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return o.redactRaw(query)
+	}
+
+	for k := range values {
+		if o.sensitive(k) {
+			values[k] = []string{"*"}
+		}
+	}
+
+	return values.Encode()
+}
+
+func (o *LoggerOptions) redactBody(contentType string, body []byte) string {
+	switch {
+	case strings.HasSuffix(contentType, "json"):
+		var v any
+		if err := json.Unmarshal(body, &v); err != nil {
+			// 超出 MaxBodySize 被截断的 json 无法解析, 按文本替换
+			break
+		}
+
+		if data, err := json.Marshal(o.redactJSON(v)); err == nil {
+			return string(data)
+		}
+	case contentType == gin.MIMEPOSTForm:
+		return o.redactQuery(string(body))
+	}
+
+	return o.redactRaw(string(body))
+}
+
+// redactRaw 按正则替换文本中 json, xml 及 key=value 形式的敏感字段的值
+func (o *LoggerOptions) redactRaw(text string) string {
+	for _, p := range o.redactText {
+		text = p.re.ReplaceAllString(text, p.repl)
+	}
+
+	return text
+}
+
+func (o *LoggerOptions) redactJSON(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if o.sensitive(k) {
+				t[k] = "*"
+			} else {
+				t[k] = o.redactJSON(val)
+			}
+		}
+	case []any:
+		for i := range t {
+			t[i] = o.redactJSON(t[i])
+		}
+	}
+
+	return v
+}
+
+type redactPattern struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+func loggableContentType(ct string) bool {
+	ct = strings.ToLower(strings.TrimSpace(ct))
+
+	return strings.HasPrefix(ct, "text/") ||
+		strings.HasSuffix(ct, "json") ||
+		strings.HasSuffix(ct, "xml") ||
+		ct == gin.MIMEPOSTForm
+}
+
+// logSampler 按秒统计 2xx 日志数, 超过阈值后按比例采样
+type logSampler struct {
+	threshold int64
+	rate      int64
+	second    atomic.Int64
+	count     atomic.Int64
+}
+
+func (s *logSampler) allow(now int64) bool {
+	if s.rate <= 1 {
+		return true
+	}
+
+	if sec := s.second.Load(); sec != now && s.second.CompareAndSwap(sec, now) {
+		s.count.Store(0)
+	}
+
+	n := s.count.Add(1) - s.threshold
+
+	return n <= 0 || n%s.rate == 0
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	max  int
+	body []byte
+}
+
+func (w *bodyLogWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *bodyLogWriter) capture(data []byte) {
+	if remain := w.max - len(w.body); remain > 0 {
+		w.body = append(w.body, data[:min(remain, len(data))]...)
+	}
+}
@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// captureLogs 将默认日志输出到 buffer, 返回每条日志解析后的字段
+func captureLogs(t *testing.T) func() []map[string]any {
+	buf := &bytes.Buffer{}
+	old := slog.Default()
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(old) })
+
+	return func() []map[string]any {
+		var entries []map[string]any
+
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+
+			entry := map[string]any{}
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatal(err)
+			}
+
+			entries = append(entries, entry)
+		}
+
+		buf.Reset()
+
+		return entries
+	}
+}
+
+func newLoggerRouter(opts LoggerOptions) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+
+	echo := func(c *gin.Context) {
+		data, _ := io.ReadAll(c.Request.Body)
+		c.Data(200, c.ContentType(), data)
+	}
+
+	r := gin.New()
+	r.Use(LoggerWithOptions(opts))
+	r.GET("/health", echo)
+	r.GET("/static/*file", echo)
+	r.GET("/api/:version/ping", echo)
+	r.GET("/users/:id", echo)
+	r.POST("/echo", echo)
+	r.GET("/missing", func(c *gin.Context) { c.Status(404) })
+
+	return r
+}
+
+func TestLoggerIgnore(t *testing.T) {
+	logs := captureLogs(t)
+	r := newLoggerRouter(LoggerOptions{IgnorePaths: []string{"/health", "/static/*", "/api/*/ping"}})
+
+	for _, target := range []string{"/health", "/static/app.css", "/api/v1/ping", "/users/1"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", target, nil))
+	}
+
+	entries := logs()
+	if len(entries) != 1 || entries[0]["uri"] != "/users/1" {
+		t.Fatalf("unexpected logs %v", entries)
+	}
+}
+
+func TestLoggerSampling(t *testing.T) {
+	s := &logSampler{threshold: 2, rate: 3}
+
+	allowed := 0
+	for i := 0; i < 11; i++ {
+		if s.allow(100) {
+			allowed++
+		}
+	}
+
+	// 前 2 条全部记录, 之后每 3 条记录 1 条
+	if allowed != 5 {
+		t.Fatalf("expected 5 allowed, got %d", allowed)
+	}
+
+	if !s.allow(101) {
+		t.Fatal("counter should reset every second")
+	}
+
+	logs := captureLogs(t)
+	r := newLoggerRouter(LoggerOptions{SampleRate: 1000})
+
+	for i := 0; i < 5; i++ {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/1", nil))
+	}
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/missing", nil))
+
+	// 非 2xx 不采样
+	entries := logs()
+	if len(entries) != 1 || entries[0]["httpCode"] != float64(404) {
+		t.Fatalf("unexpected logs %v", entries)
+	}
+}
+
+func TestLoggerFields(t *testing.T) {
+	logs := captureLogs(t)
+
+	req := httptest.NewRequest("GET", "/users/42?page=1&access_token=abc", nil)
+	req.Header.Set("User-Agent", "rain-test")
+	req.Header.Set("X-Request-Id", "r1")
+	req.Header.Set("X-Api-Token", "abc")
+
+	newLoggerRouter(LoggerOptions{}).ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs()
+	if len(entries) != 1 || entries[0]["uri"] != "/users/42" {
+		t.Fatalf("unexpected logs %v", entries)
+	}
+
+	for _, k := range []string{"query", "userAgent", "headers"} {
+		if _, ok := entries[0][k]; ok {
+			t.Fatalf("unexpected field %s", k)
+		}
+	}
+
+	newLoggerRouter(LoggerOptions{
+		Query:     true,
+		UserAgent: true,
+		Route:     true,
+		Headers:   []string{"X-Request-Id", "X-Api-Token"},
+	}).ServeHTTP(httptest.NewRecorder(), req)
+
+	entry := logs()[0]
+	headers, _ := entry["headers"].(map[string]any)
+
+	if entry["uri"] != "/users/:id" || entry["userAgent"] != "rain-test" ||
+		headers["X-Request-Id"] != "r1" || headers["X-Api-Token"] != "*" ||
+		strings.Contains(entry["query"].(string), "abc") || !strings.Contains(entry["query"].(string), "page=1") {
+		t.Fatalf("unexpected log %v", entry)
+	}
+}
+
+func TestLoggerBody(t *testing.T) {
+	logs := captureLogs(t)
+	r := newLoggerRouter(LoggerOptions{RequestBody: true, ResponseBody: true, MaxBodySize: 32})
+
+	post := func(ct, body string) (map[string]any, string) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/echo", strings.NewReader(body))
+		req.Header.Set("Content-Type", ct)
+		r.ServeHTTP(w, req)
+
+		entries := logs()
+		if len(entries) != 1 {
+			t.Fatalf("unexpected logs %v", entries)
+		}
+
+		return entries[0], w.Body.String()
+	}
+
+	// 超出 MaxBodySize 时截断, 后续 handler 仍读取完整的请求体
+	long := strings.Repeat("a", 100)
+	entry, resp := post("text/plain", long)
+
+	if resp != long || entry["requestBody"] != long[:32] || entry["responseBody"] != long[:32] {
+		t.Fatalf("unexpected log %v", entry)
+	}
+
+	cases := []struct {
+		ct, body, want string
+	}{
+		{gin.MIMEJSON, `{"user":"a","password":"x1"}`, `"password":"*"`},
+		{gin.MIMEJSON, `{"password":"hunter2hunter2hunter2hunter2"}`, `"password":"*"`},
+		{gin.MIMEJSON, `{"user":"abcdefgh","api_token":"hunter2hunter2"}`, `"api_token":"*"`},
+		{gin.MIMEPOSTForm, "user=a&password=hunter2", "password=%2A"},
+		{gin.MIMEXML, "<login><password>hunter2</password></login>", "<password>*<"},
+		{gin.MIMEPlain, "user=a token=hunter2", "token=*"},
+	}
+
+	for _, c := range cases {
+		entry, _ = post(c.ct, c.body)
+
+		for _, k := range []string{"requestBody", "responseBody"} {
+			v, _ := entry[k].(string)
+			if strings.Contains(v, "hunter2") || strings.Contains(v, "x1") || !strings.Contains(v, c.want) {
+				t.Fatalf("%s %s not redacted: %q", c.ct, k, v)
+			}
+		}
+	}
+}
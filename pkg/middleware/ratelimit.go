@@ -0,0 +1,328 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	RateLimitTokenBucket   = "token_bucket"
+	RateLimitSlidingWindow = "sliding_window"
+)
+
+type RateLimitOptions struct {
+	Algorithm string        // token_bucket, sliding_window, 默认 token_bucket
+	Limit     int           // 每个窗口允许的请求数 (令牌桶为每个窗口补充的令牌数)
+	Burst     int           // 令牌桶容量, 默认等于 Limit
+	Window    time.Duration // 默认 1s
+	Prefix    string        // key 前缀, 默认 rate_limit:
+	KeyFunc   func(c *gin.Context) string
+	Store     RateLimitStore
+}
+
+func (o *RateLimitOptions) init() {
+	if o.Algorithm == "" {
+		o.Algorithm = RateLimitTokenBucket
+	}
+
+	if o.Window <= 0 {
+		o.Window = time.Second
+	}
+
+	if o.Burst <= 0 {
+		o.Burst = o.Limit
+	}
+
+	if o.Prefix == "" {
+		o.Prefix = "rate_limit:"
+	}
+
+	if o.KeyFunc == nil {
+		o.KeyFunc = RateLimitKeyByIP
+	}
+
+	if o.Store == nil {
+		o.Store = NewMemoryRateLimitStore()
+	}
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, opts *RateLimitOptions) (*RateLimitResult, error)
+}
+
+func RateLimit(opts RateLimitOptions) gin.HandlerFunc {
+	opts.init()
+
+	return func(c *gin.Context) {
+		if opts.Limit <= 0 {
+			return
+		}
+
+		key := opts.KeyFunc(c)
+		if key == "" {
+			return
+		}
+
+		res, err := opts.Store.Take(c.Request.Context(), opts.Prefix+key, &opts)
+		if err != nil {
+			// 存储异常时放行, 避免限流组件故障导致服务不可用
+			slog.Error("rate-limit", slog.String("key", key), slog.String("error", err.Error()))
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(opts.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+
+		if res.Allowed {
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds()))))
+		AbortWithStatus(c, http.StatusTooManyRequests, "too many requests")
+	}
+}
+
+func RateLimitKeyByIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+func RateLimitKeyByRoute(c *gin.Context) string {
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
+
+	return c.Request.Method + ":" + route
+}
+
+func RateLimitKeyByIPRoute(c *gin.Context) string {
+	return RateLimitKeyByIP(c) + ":" + RateLimitKeyByRoute(c)
+}
+
+var _ RateLimitStore = &MemoryRateLimitStore{}
+
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*rateLimitEntry
+	sweepAt time.Time
+}
+
+type rateLimitEntry struct {
+	// token bucket
+	tokens float64
+	last   time.Time
+
+	// sliding window
+	index int64
+	cur   int
+	prev  int
+
+	expire time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: map[string]*rateLimitEntry{},
+		sweepAt: time.Now(),
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, opts *RateLimitOptions) (*RateLimitResult, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	e, ok := s.buckets[key]
+	if !ok {
+		e = &rateLimitEntry{tokens: float64(opts.Burst), last: now}
+		s.buckets[key] = e
+	}
+
+	if opts.Algorithm == RateLimitSlidingWindow {
+		return e.slidingWindow(now, opts), nil
+	}
+
+	return e.tokenBucket(now, opts), nil
+}
+
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.sweepAt) < time.Minute {
+		return
+	}
+
+	s.sweepAt = now
+
+	for k, v := range s.buckets {
+		if now.After(v.expire) {
+			delete(s.buckets, k)
+		}
+	}
+}
+
+func (e *rateLimitEntry) tokenBucket(now time.Time, opts *RateLimitOptions) *RateLimitResult {
+	rate := float64(opts.Limit) / float64(opts.Window) // tokens per nanosecond
+
+	if elapsed := now.Sub(e.last); elapsed > 0 {
+		e.tokens = math.Min(float64(opts.Burst), e.tokens+float64(elapsed)*rate)
+	}
+	e.last = now
+	e.expire = now.Add(time.Duration(float64(opts.Burst) / rate))
+
+	if e.tokens < 1 {
+		return &RateLimitResult{RetryAfter: time.Duration((1 - e.tokens) / rate)}
+	}
+
+	e.tokens--
+
+	return &RateLimitResult{Allowed: true, Remaining: int(e.tokens)}
+}
+
+// slidingWindow 使用滑动窗口计数法: 以上一窗口计数按剩余比例加权, 与当前窗口计数求和
+func (e *rateLimitEntry) slidingWindow(now time.Time, opts *RateLimitOptions) *RateLimitResult {
+	window := int64(opts.Window)
+	index := now.UnixNano() / window
+
+	switch e.index {
+	case index:
+	case index - 1:
+		e.prev, e.cur = e.cur, 0
+	default:
+		e.prev, e.cur = 0, 0
+	}
+	e.index = index
+	e.expire = now.Add(2 * opts.Window)
+
+	offset := now.UnixNano() % window
+	elapsed := float64(offset) / float64(window)
+	count := float64(e.prev)*(1-elapsed) + float64(e.cur)
+
+	if count+1 > float64(opts.Limit) {
+		retry := window - offset
+		if e.cur+1 <= opts.Limit && e.prev > 0 {
+			retry = int64(math.Ceil((1 - float64(opts.Limit-e.cur-1)/float64(e.prev) - elapsed) * float64(window)))
+		}
+
+		return &RateLimitResult{RetryAfter: time.Duration(retry)}
+	}
+
+	e.cur++
+
+	return &RateLimitResult{Allowed: true, Remaining: int(float64(opts.Limit) - count - 1)}
+}
+
+var _ RateLimitStore = &RedisRateLimitStore{}
+
+// tokenBucketScript KEYS[1]: key, ARGV: limit, window(ms), burst
+var tokenBucketScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local rate = limit / window
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1]) or burst
+local ts = tonumber(data[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+if tokens < 1 then
+	return {0, 0, math.ceil((1 - tokens) / rate)}
+end
+
+tokens = tokens - 1
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate))
+
+return {1, math.floor(tokens), 0}
+`)
+
+// slidingWindowScript KEYS[1]: key, ARGV: limit, window(ms)
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local index = math.floor(now / window)
+
+local data = redis.call('HMGET', KEYS[1], 'index', 'cur', 'prev')
+local last = tonumber(data[1]) or index
+local cur = tonumber(data[2]) or 0
+local prev = tonumber(data[3]) or 0
+
+if last == index - 1 then
+	prev = cur
+	cur = 0
+elseif last ~= index then
+	prev = 0
+	cur = 0
+end
+
+local offset = now % window
+local elapsed = offset / window
+local count = prev * (1 - elapsed) + cur
+
+if count + 1 > limit then
+	local retry = window - offset
+	if cur + 1 <= limit and prev > 0 then
+		retry = math.ceil((1 - (limit - cur - 1) / prev - elapsed) * window)
+	end
+	return {0, 0, retry}
+end
+
+cur = cur + 1
+redis.call('HSET', KEYS[1], 'index', index, 'cur', cur, 'prev', prev)
+redis.call('PEXPIRE', KEYS[1], window * 2)
+
+return {1, math.floor(limit - count - 1), 0}
+`)
+
+type RedisRateLimitStore struct {
+	client redis.Scripter
+}
+
+func NewRedisRateLimitStore(client redis.Scripter) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client}
+}
+
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, opts *RateLimitOptions) (*RateLimitResult, error) {
+	window := opts.Window.Milliseconds()
+	if window <= 0 {
+		window = 1
+	}
+
+	var cmd *redis.Cmd
+	if opts.Algorithm == RateLimitSlidingWindow {
+		cmd = slidingWindowScript.Run(ctx, s.client, []string{key}, opts.Limit, window)
+	} else {
+		cmd = tokenBucketScript.Run(ctx, s.client, []string{key}, opts.Limit, window, opts.Burst)
+	}
+
+	res, err := cmd.Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateLimitResult{
+		Allowed:    res[0] == 1,
+		Remaining:  int(res[1]),
+		RetryAfter: time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
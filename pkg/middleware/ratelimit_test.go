@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func testRateLimitStores(t *testing.T) map[string]func() RateLimitStore {
+	s := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return map[string]func() RateLimitStore{
+		"memory": func() RateLimitStore { return NewMemoryRateLimitStore() },
+		"redis": func() RateLimitStore {
+			s.FlushAll()
+			return NewRedisRateLimitStore(client)
+		},
+	}
+}
+
+func TestRateLimitStore(t *testing.T) {
+	for name, newStore := range testRateLimitStores(t) {
+		for _, algorithm := range []string{RateLimitTokenBucket, RateLimitSlidingWindow} {
+			opts := &RateLimitOptions{Algorithm: algorithm, Limit: 3, Window: time.Second}
+			opts.init()
+
+			store := newStore()
+
+			for i := 0; i < 3; i++ {
+				res, err := store.Take(context.Background(), "k", opts)
+				if err != nil {
+					t.Fatalf("%s %s: %s", name, algorithm, err)
+				}
+
+				if !res.Allowed || res.Remaining != 2-i {
+					t.Fatalf("%s %s: request %d should be allowed, got %+v", name, algorithm, i, res)
+				}
+			}
+
+			res, _ := store.Take(context.Background(), "k", opts)
+			if res.Allowed {
+				t.Fatalf("%s %s: request should be limited", name, algorithm)
+			}
+
+			if res.RetryAfter <= 0 || res.RetryAfter > time.Second {
+				t.Fatalf("%s %s: unexpected retry after %s", name, algorithm, res.RetryAfter)
+			}
+
+			if res, _ = store.Take(context.Background(), "other", opts); !res.Allowed {
+				t.Fatalf("%s %s: other key should be allowed", name, algorithm)
+			}
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for name, newStore := range testRateLimitStores(t) {
+		r := gin.New()
+		r.Use(RateLimit(RateLimitOptions{Limit: 2, Window: time.Minute, Store: newStore()}))
+		r.GET("/", func(c *gin.Context) { c.String(200, "ok") })
+
+		for i := 0; i < 3; i++ {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+			h := w.Header()
+			if h.Get("X-RateLimit-Limit") != "2" || h.Get("X-RateLimit-Remaining") != strconv.Itoa(max(1-i, 0)) {
+				t.Fatalf("%s: request %d unexpected headers %v", name, i, h)
+			}
+
+			if i < 2 {
+				if w.Code != 200 || h.Get("Retry-After") != "" {
+					t.Fatalf("%s: request %d should be allowed, got %d", name, i, w.Code)
+				}
+
+				continue
+			}
+
+			retry, _ := strconv.Atoi(h.Get("Retry-After"))
+			if w.Code != http.StatusTooManyRequests || retry <= 0 || retry > 60 {
+				t.Fatalf("%s: expected 429 with Retry-After, got %d %v", name, w.Code, h)
+			}
+		}
+
+		// 不同客户端互不影响
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = "10.0.0.2:1234"
+		r.ServeHTTP(w, req)
+
+		if w.Code != 200 {
+			t.Fatalf("%s: other client should be allowed, got %d", name, w.Code)
+		}
+	}
+}
@@ -45,19 +45,12 @@ func HandleRecover(c *gin.Context, err any) {
 	}
 
 	stack := stack(3)
-	httpRequest, _ := httputil.DumpRequest(c.Request, false)
-	headers := strings.Split(string(httpRequest), "\r\n")
-
-	for idx, header := range headers {
-		if current := strings.Split(header, ":"); len(current) > 0 && current[0] == "Authorization" {
-			headers[idx] = current[0] + ": *"
-		}
-	}
+	httpRequest := DumpRequest(c.Request)
 
 	if brokenPipe {
-		slog.Error(fmt.Sprintf("%s\n%s", err, string(httpRequest)))
+		slog.Error(fmt.Sprintf("%s\n%s", err, httpRequest))
 	} else if gin.IsDebugging() {
-		slog.Error(fmt.Sprintf("[Recovery] panic recovered:\n%s\n%s\n%s", strings.Join(headers, "\r\n"), err, stack))
+		slog.Error(fmt.Sprintf("[Recovery] panic recovered:\n%s\n%s\n%s", httpRequest, err, stack))
 	} else {
 		slog.Error(fmt.Sprintf("[Recovery] panic recovered:\n%s\n%s", err, stack))
 	}
@@ -66,11 +59,7 @@ func HandleRecover(c *gin.Context, err any) {
 		_ = c.Error(err.(error))
 		c.Abort()
 	} else {
-		c.JSON(http.StatusOK, struct {
-			Data any    `json:"data"`
-			Code int    `json:"code"`
-			Msg  string `json:"msg"`
-		}{
+		c.JSON(http.StatusOK, response{
 			Data: struct{}{},
 			Code: 500,
 			Msg:  "",
@@ -78,6 +67,30 @@ func HandleRecover(c *gin.Context, err any) {
 	}
 }
 
+// DumpRequest 输出请求行及请求头, Authorization 及 sensitiveHeaders 中的请求头会被替换为 *
+func DumpRequest(r *http.Request, sensitiveHeaders ...string) string {
+	httpRequest, _ := httputil.DumpRequest(r, false)
+	headers := strings.Split(string(httpRequest), "\r\n")
+
+	sensitiveHeaders = append(sensitiveHeaders, "Authorization")
+
+	for idx, header := range headers {
+		name, _, ok := strings.Cut(header, ":")
+		if !ok {
+			continue
+		}
+
+		for _, v := range sensitiveHeaders {
+			if strings.EqualFold(name, v) {
+				headers[idx] = name + ": *"
+				break
+			}
+		}
+	}
+
+	return strings.Join(headers, "\r\n")
+}
+
 // stack returns a nicely formatted stack frame, skipping skip frames.
 func stack(skip int) []byte {
 	buf := new(bytes.Buffer) // the returned data
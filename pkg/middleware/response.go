@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type response struct {
+	Data any    `json:"data"`
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func AbortWithStatus(c *gin.Context, status int, msg string) {
+	c.AbortWithStatusJSON(status, response{
+		Data: struct{}{},
+		Code: status,
+		Msg:  msg,
+	})
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSecure(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	r.Use(Secure(SecureOptions{HSTSMaxAge: time.Hour, HSTSIncludeSubdomains: true, ReferrerPolicy: "no-referrer"}))
+	r.GET("/", func(c *gin.Context) { c.String(200, "ok") })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	h := w.Header()
+	if h.Get("X-Frame-Options") != "DENY" || h.Get("X-Content-Type-Options") != "nosniff" ||
+		h.Get("Referrer-Policy") != "no-referrer" {
+		t.Fatalf("unexpected headers %v", h)
+	}
+
+	// 仅 HTTPS 响应设置 HSTS
+	if h.Get("Strict-Transport-Security") != "" {
+		t.Fatal("HSTS should not be sent over plain HTTP")
+	}
+
+	tlsReq := httptest.NewRequest("GET", "/", nil)
+	tlsReq.TLS = &tls.ConnectionState{}
+
+	proxyReq := httptest.NewRequest("GET", "/", nil)
+	proxyReq.Header.Set("X-Forwarded-Proto", "https")
+
+	for i, req := range []*http.Request{tlsReq, proxyReq} {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if v := w.Header().Get("Strict-Transport-Security"); v != "max-age=3600; includeSubDomains" {
+			t.Fatalf("request %d: unexpected HSTS %q", i, v)
+		}
+	}
+}
@@ -7,18 +7,25 @@ import (
 	"log/slog"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/yrbb/rain/pkg/logger"
 	"github.com/yrbb/rain/pkg/utils"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// ormLogger 可通过 logger.SetNamedLevel("orm", "debug") 单独开启 SQL 日志
+var ormLogger = logger.Named("orm")
+
 type Orm struct {
-	db     *sql.DB
-	config *Config
-	models sync.Map
-	exitCh chan struct{}
+	mu        sync.Mutex // 串行修改连接池及配置
+	db        atomic.Pointer[sql.DB]
+	config    atomic.Pointer[Config] // 修改时整体替换, 查询中读取时无需加锁
+	models    sync.Map
+	exitCh    chan struct{}
+	closeOnce sync.Once
 }
 
 type Config struct {
@@ -40,10 +47,12 @@ func New(c *Config) (*Orm, error) {
 	c.PoolThreshold = utils.If(c.PoolThreshold == 0, 80, c.PoolThreshold)
 
 	o := &Orm{
-		config: c,
 		exitCh: make(chan struct{}),
 	}
 
+	cfg := *c
+	o.config.Store(&cfg)
+
 	db, err := sql.Open(c.Type, c.Addr)
 	if err != nil {
 		return nil, err
@@ -53,7 +62,7 @@ func New(c *Config) (*Orm, error) {
 		return nil, err
 	}
 
-	o.db = db
+	o.db.Store(db)
 
 	if c.MaxIdleConns > 0 {
 		o.SetMaxIdleConns(c.MaxIdleConns)
@@ -73,32 +82,78 @@ func New(c *Config) (*Orm, error) {
 }
 
 func (o *Orm) SetMaxIdleConns(n int) {
-	o.db.SetMaxIdleConns(n)
+	o.DB().SetMaxIdleConns(n)
 }
 
 func (o *Orm) SetMaxOpenConns(n int) {
-	o.config.MaxOpenConns = n
-	o.db.SetMaxOpenConns(n)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	cfg := *o.config.Load()
+	cfg.MaxOpenConns = n
+	o.config.Store(&cfg)
+
+	o.DB().SetMaxOpenConns(n)
 }
 
 func (o *Orm) SetConnMaxLifetime(d time.Duration) {
-	o.db.SetConnMaxLifetime(d)
+	o.DB().SetConnMaxLifetime(d)
 }
 
 func (o *Orm) Stats() sql.DBStats {
-	return o.db.Stats()
+	return o.DB().Stats()
 }
 
 func (o *Orm) Close() {
-	if err := o.db.Close(); err != nil {
-		slog.Error("close db err", slog.String("error", err.Error()))
-	}
+	o.closeOnce.Do(func() {
+		if err := o.DB().Close(); err != nil {
+			ormLogger.Error("close db err", slog.String("error", err.Error()))
+		}
 
-	o.exitCh <- struct{}{}
+		close(o.exitCh)
+	})
 }
 
 func (o *Orm) DB() *sql.DB {
-	return o.db
+	return o.db.Load()
+}
+
+// Config 返回当前配置的副本
+func (o *Orm) Config() Config {
+	return *o.config.Load()
+}
+
+// Reconnect 使用新的连接地址创建连接池并替换当前连接池, 返回旧的连接池, 由调用方在执行中的查询完成后关闭.
+// 已获取的 *Orm 继续可用, 之后的查询使用新的连接池
+func (o *Orm) Reconnect(typ, addr string) (*sql.DB, error) {
+	db, err := sql.Open(typ, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	cfg := *o.config.Load()
+	cfg.Type = typ
+	cfg.Addr = addr
+
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+
+	o.config.Store(&cfg)
+
+	return o.db.Swap(db), nil
 }
 
 func (o *Orm) NewSession() *Session {
@@ -114,23 +169,24 @@ func (o *Orm) logStatus() {
 		case <-ticker.C:
 		}
 
-		if o.config.PoolThreshold == -1 {
+		c := o.config.Load()
+		if c.PoolThreshold == -1 {
 			return
 		}
 
-		used := o.db.Stats().OpenConnections
-		tNum := o.config.MaxOpenConns * o.config.PoolThreshold / 100
+		used := o.DB().Stats().OpenConnections
+		tNum := c.MaxOpenConns * c.PoolThreshold / 100
 
 		if used > tNum {
-			percent := float32(used) / float32(o.config.MaxOpenConns) * 100
+			percent := float32(used) / float32(c.MaxOpenConns) * 100
 
-			slog.Warn(fmt.Sprintf(
+			ormLogger.Warn(fmt.Sprintf(
 				"数据库连接使用率高 [%s], used: %d/%d, percent: %.2f, threshold: %d%%",
-				o.config.Name,
+				c.Name,
 				used,
-				o.config.MaxOpenConns,
+				c.MaxOpenConns,
 				percent,
-				o.config.PoolThreshold,
+				c.PoolThreshold,
 			))
 		}
 	}
@@ -1,12 +1,11 @@
 package orm
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
 	"time"
-
-	"github.com/yrbb/rain/pkg/logger"
 )
 
 type Session struct {
@@ -52,14 +51,14 @@ func (s *Session) SetTimeout(t time.Duration) *Session {
 func (s *Session) after(typ string, err error) {
 	s.queryTime = float64(time.Since(s.queryStart).Milliseconds())
 
-	if s.orm.config.SlowThreshold > 0 && s.queryTime >= s.orm.config.SlowThreshold {
-		slog.Warn(fmt.Sprintf(
+	if threshold := s.orm.config.Load().SlowThreshold; threshold > 0 && s.queryTime >= threshold {
+		ormLogger.Warn(fmt.Sprintf(
 			"long query [%.6f], sql: %s, args: %v",
 			s.queryTime, s.sql, s.args,
 		))
 	}
 
-	if typ == "query" && logger.GetLevel() != slog.LevelDebug {
+	if typ == "query" && !ormLogger.Enabled(context.Background(), slog.LevelDebug) {
 		return
 	}
 
@@ -78,9 +77,9 @@ func (s *Session) after(typ string, err error) {
 	}
 
 	if typ == "query" {
-		slog.Debug("query", fields...)
+		ormLogger.Debug("query", fields...)
 	} else {
-		slog.Info("exec", fields...)
+		ormLogger.Info("exec", fields...)
 	}
 }
 
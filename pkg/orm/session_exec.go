@@ -204,7 +204,7 @@ func (s *Session) Exec(sqlStr string, values ...any) (res sql.Result, err error)
 	if s.tx != nil {
 		res, err = s.tx.ExecContext(ctx, sqlStr, values...)
 	} else {
-		res, err = s.orm.db.ExecContext(ctx, sqlStr, values...)
+		res, err = s.orm.DB().ExecContext(ctx, sqlStr, values...)
 	}
 
 	return
@@ -37,7 +37,7 @@ func (s *Session) Query(sqlStr string, values []any, errs ...error) (rows *sql.R
 	if s.tx != nil {
 		rows, err = s.tx.QueryContext(ctx, sqlStr, values...)
 	} else {
-		rows, err = s.orm.db.QueryContext(ctx, sqlStr, values...)
+		rows, err = s.orm.DB().QueryContext(ctx, sqlStr, values...)
 	}
 
 	return
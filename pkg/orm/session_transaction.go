@@ -9,7 +9,7 @@ func (s *Session) Begin() error {
 		return ErrTransExist
 	}
 
-	tx, err := s.orm.db.Begin()
+	tx, err := s.orm.DB().Begin()
 	if err != nil {
 		return err
 	}
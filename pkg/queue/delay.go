@@ -0,0 +1,176 @@
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrDuplicate 相同 ID 的延迟任务已存在
+var ErrDuplicate = errors.New("queue: duplicate delayed job")
+
+// scheduleScript KEYS: zset, hash; ARGV: id, 执行时间(ms), 数据
+var scheduleScript = redis.NewScript(`
+if redis.call('HSETNX', KEYS[2], ARGV[1], ARGV[3]) == 0 then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+return 1
+`)
+
+// cancelScript KEYS: zset, hash; ARGV: id
+var cancelScript = redis.NewScript(`
+redis.call('HDEL', KEYS[2], ARGV[1])
+return redis.call('ZREM', KEYS[1], ARGV[1])
+`)
+
+// promoteScript 将到期的延迟任务移入 stream, KEYS: zset, hash, stream; ARGV: 当前时间(ms), 数量, stream 最大长度
+var promoteScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+local maxlen = tonumber(ARGV[3])
+
+for _, id in ipairs(ids) do
+	local data = redis.call('HGET', KEYS[2], id)
+	if data then
+		local sep = string.find(data, '|', 1, true)
+		local created = string.sub(data, 1, sep - 1)
+		local payload = string.sub(data, sep + 1)
+
+		if maxlen > 0 then
+			redis.call('XADD', KEYS[3], 'MAXLEN', '~', maxlen, '*', 'payload', payload, 'created', created, 'key', id)
+		else
+			redis.call('XADD', KEYS[3], '*', 'payload', payload, 'created', created, 'key', id)
+		end
+	end
+
+	redis.call('ZREM', KEYS[1], id)
+	redis.call('HDEL', KEYS[2], id)
+end
+
+return #ids
+`)
+
+type DelayedJob struct {
+	ID        string
+	Queue     string
+	Payload   []byte
+	RunAt     time.Time
+	CreatedAt time.Time
+}
+
+// EnqueueIn 添加 delay 后执行的任务, 参见 EnqueueAt
+func (q *Queue) EnqueueIn(ctx context.Context, queue string, payload any, delay time.Duration, id ...string) (string, error) {
+	return q.EnqueueAt(ctx, queue, payload, time.Now().Add(delay), id...)
+}
+
+// EnqueueAt 添加在 at 执行的任务, 到期后移入队列由 queue:work 消费.
+// id 为幂等键, 相同 id 的任务未执行前重复添加返回 ErrDuplicate, 不传时随机生成, 可用于 Cancel
+func (q *Queue) EnqueueAt(ctx context.Context, queue string, payload any, at time.Time, id ...string) (string, error) {
+	data, err := encodePayload(payload)
+	if err != nil {
+		return "", err
+	}
+
+	jobID := newJobID()
+	if len(id) > 0 && id[0] != "" {
+		jobID = id[0]
+	}
+
+	value := strconv.FormatInt(time.Now().UnixMilli(), 10) + "|" + string(data)
+
+	ok, err := scheduleScript.Run(
+		ctx,
+		q.client,
+		[]string{q.delayedSet(queue), q.delayedData(queue)},
+		jobID, at.UnixMilli(), value,
+	).Int()
+	if err != nil {
+		return "", err
+	}
+
+	if ok == 0 {
+		return jobID, ErrDuplicate
+	}
+
+	return jobID, nil
+}
+
+// Cancel 取消未到期的延迟任务, 任务不存在或已移入队列时返回 false
+func (q *Queue) Cancel(ctx context.Context, queue, id string) (bool, error) {
+	n, err := cancelScript.Run(ctx, q.client, []string{q.delayedSet(queue), q.delayedData(queue)}, id).Int()
+
+	return n > 0, err
+}
+
+// DelayedCount 返回未到期的延迟任务数
+func (q *Queue) DelayedCount(ctx context.Context, queue string) (int64, error) {
+	return q.client.ZCard(ctx, q.delayedSet(queue)).Result()
+}
+
+// Delayed 按执行时间返回从 offset 开始的 count 个延迟任务
+func (q *Queue) Delayed(ctx context.Context, queue string, offset, count int64) ([]DelayedJob, error) {
+	items, err := q.client.ZRangeWithScores(ctx, q.delayedSet(queue), offset, offset+count-1).Result()
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+
+	ids := make([]string, len(items))
+	for i, v := range items {
+		ids[i] = v.Member.(string)
+	}
+
+	values, err := q.client.HMGet(ctx, q.delayedData(queue), ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]DelayedJob, 0, len(items))
+
+	for i, v := range items {
+		data, ok := values[i].(string)
+		if !ok {
+			// 已被取消或移入队列
+			continue
+		}
+
+		job := DelayedJob{
+			ID:    ids[i],
+			Queue: queue,
+			RunAt: time.UnixMilli(int64(v.Score)),
+		}
+
+		created, payload, _ := strings.Cut(data, "|")
+		job.Payload = []byte(payload)
+
+		if ms, err := strconv.ParseInt(created, 10, 64); err == nil {
+			job.CreatedAt = time.UnixMilli(ms)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// promote 将到期的延迟任务移入 stream, 返回移动的任务数
+func (q *Queue) promote(ctx context.Context, queue string) (int, error) {
+	return promoteScript.Run(
+		ctx,
+		q.client,
+		[]string{q.delayedSet(queue), q.delayedData(queue), q.stream(queue)},
+		time.Now().UnixMilli(), promoteBatch, q.opts.MaxLen,
+	).Int()
+}
+
+func newJobID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
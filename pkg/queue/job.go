@@ -0,0 +1,61 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Handler func(ctx context.Context, job *Job) error
+
+type Job struct {
+	ID        string
+	Key       string // 延迟任务的 ID, 普通任务为空
+	Queue     string
+	Payload   []byte
+	Attempts  int // 当前为第几次执行, 从 1 开始
+	CreatedAt time.Time
+}
+
+// Unmarshal 将 json 格式的 Payload 解析到 v
+func (j *Job) Unmarshal(v any) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+func encodePayload(payload any) ([]byte, error) {
+	switch v := payload.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return json.Marshal(v)
+	}
+}
+
+func parseJob(queue string, msg redis.XMessage, attempts int) *Job {
+	job := &Job{
+		ID:       msg.ID,
+		Queue:    queue,
+		Attempts: attempts,
+	}
+
+	if v, ok := msg.Values["key"].(string); ok {
+		job.Key = v
+	}
+
+	if v, ok := msg.Values["payload"].(string); ok {
+		job.Payload = []byte(v)
+	}
+
+	if v, ok := msg.Values["created"].(string); ok {
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			job.CreatedAt = time.UnixMilli(ms)
+		}
+	}
+
+	return job
+}
@@ -0,0 +1,274 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrRunning 队列已启动, 不能再注册 handler
+	ErrRunning = errors.New("queue: already running")
+
+	// ErrNoHandler 未注册任何 handler
+	ErrNoHandler = errors.New("queue: no handler")
+)
+
+type Options struct {
+	Prefix      string                           // stream key 前缀, 默认 queue:
+	Group       string                           // 消费组, 默认 default
+	Consumer    string                           // 消费者名, 默认 hostname-pid
+	Concurrency int                              // 每个队列同时执行的任务数, 默认 10
+	MaxRetries  int                              // 最大重试次数, 超过后移入死信队列, 默认 3, 小于 0 时不重试
+	Backoff     func(attempts int) time.Duration // 第 attempts 次执行失败后的重试间隔, 默认 1s 起指数增长, 最大 5 分钟
+	ClaimIdle   time.Duration                    // 任务超过该时间未确认视为消费者崩溃, 由其他消费者接管, 默认 5 分钟
+	Block       time.Duration                    // XREADGROUP 阻塞时间, 默认 2s
+	MaxLen      int64                            // stream 近似最大长度, 0 表示不限制
+	Submit      func(task func()) error          // 执行任务的协程池, 默认为每个任务创建 goroutine
+}
+
+func (o *Options) init() {
+	if o.Prefix == "" {
+		o.Prefix = "queue:"
+	}
+
+	if o.Group == "" {
+		o.Group = "default"
+	}
+
+	if o.Consumer == "" {
+		host, _ := os.Hostname()
+		o.Consumer = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+
+	if o.Concurrency <= 0 {
+		o.Concurrency = 10
+	}
+
+	if o.MaxRetries == 0 {
+		o.MaxRetries = 3
+	}
+
+	if o.Backoff == nil {
+		o.Backoff = ExponentialBackoff(time.Second, 5*time.Minute)
+	}
+
+	if o.ClaimIdle <= 0 {
+		o.ClaimIdle = 5 * time.Minute
+	}
+
+	if o.Block <= 0 {
+		o.Block = 2 * time.Second
+	}
+
+	if o.Submit == nil {
+		o.Submit = func(task func()) error {
+			go task()
+			return nil
+		}
+	}
+}
+
+// ExponentialBackoff 返回 base * 2^(attempts-1), 不超过 max
+func ExponentialBackoff(base, max time.Duration) func(attempts int) time.Duration {
+	return func(attempts int) time.Duration {
+		d := base
+		for i := 1; i < attempts && d < max; i++ {
+			d *= 2
+		}
+
+		if d > max {
+			d = max
+		}
+
+		return d
+	}
+}
+
+type Queue struct {
+	client   redis.UniversalClient
+	opts     Options
+	handlers map[string]Handler
+
+	mu      sync.Mutex
+	running bool
+	cancel  context.CancelFunc // 停止拉取任务
+	abort   context.CancelFunc // 取消执行中任务的 ctx
+	jobCtx  context.Context
+	loops   sync.WaitGroup
+	jobs    sync.WaitGroup
+}
+
+func New(client redis.UniversalClient, opts Options) *Queue {
+	opts.init()
+
+	return &Queue{
+		client:   client,
+		opts:     opts,
+		handlers: map[string]Handler{},
+	}
+}
+
+// Enqueue 添加任务, payload 为 []byte 或 string 时原样写入, 其他类型编码为 json
+func (q *Queue) Enqueue(ctx context.Context, queue string, payload any) (string, error) {
+	data, err := encodePayload(payload)
+	if err != nil {
+		return "", err
+	}
+
+	args := &redis.XAddArgs{
+		Stream: q.stream(queue),
+		Values: []any{"payload", data, "created", time.Now().UnixMilli()},
+	}
+
+	if q.opts.MaxLen > 0 {
+		args.MaxLen = q.opts.MaxLen
+		args.Approx = true
+	}
+
+	return q.client.XAdd(ctx, args).Result()
+}
+
+func (q *Queue) Handle(queue string, handler Handler) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running {
+		return ErrRunning
+	}
+
+	q.handlers[queue] = handler
+
+	return nil
+}
+
+// Queues 返回已注册 handler 的队列名
+func (q *Queue) Queues() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	names := make([]string, 0, len(q.handlers))
+	for k := range q.handlers {
+		names = append(names, k)
+	}
+
+	return names
+}
+
+// Start 创建消费组并开始消费, queues 为空时消费所有已注册的队列
+func (q *Queue) Start(ctx context.Context, queues ...string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running {
+		return ErrRunning
+	}
+
+	if len(queues) == 0 {
+		for k := range q.handlers {
+			queues = append(queues, k)
+		}
+	}
+
+	if len(queues) == 0 {
+		return ErrNoHandler
+	}
+
+	workers := make([]*worker, 0, len(queues))
+
+	for _, name := range queues {
+		handler, ok := q.handlers[name]
+		if !ok {
+			return fmt.Errorf("queue: no handler for %s", name)
+		}
+
+		// 从 0 开始消费, 消费组创建前写入的任务也会被处理
+		err := q.client.XGroupCreateMkStream(ctx, q.stream(name), q.opts.Group, "0").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return err
+		}
+
+		workers = append(workers, newWorker(q, name, handler))
+	}
+
+	var loopCtx context.Context
+	loopCtx, q.cancel = context.WithCancel(context.Background())
+	q.jobCtx, q.abort = context.WithCancel(context.Background())
+	q.running = true
+
+	for _, w := range workers {
+		q.loops.Add(2)
+		go w.read(loopCtx)
+		go w.reclaim(loopCtx)
+	}
+
+	return nil
+}
+
+// Stop 停止拉取任务并等待执行中的任务完成, ctx 结束时取消执行中任务的 ctx 并返回 ctx.Err(),
+// 未确认的任务会在 ClaimIdle 后由其他消费者接管
+func (q *Queue) Stop(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.running {
+		return nil
+	}
+
+	q.running = false
+	q.cancel()
+	q.loops.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		q.jobs.Wait()
+		close(done)
+	}()
+
+	defer q.abort()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Pending 返回队列中已投递未确认的任务数
+func (q *Queue) Pending(ctx context.Context, queue string) (int64, error) {
+	res, err := q.client.XPending(ctx, q.stream(queue), q.opts.Group).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
+
+// DeadLetters 返回死信队列中最早的 count 个任务
+func (q *Queue) DeadLetters(ctx context.Context, queue string, count int64) ([]redis.XMessage, error) {
+	return q.client.XRangeN(ctx, q.deadStream(queue), "-", "+", count).Result()
+}
+
+// 同一队列的 key 使用 hash tag, cluster 模式下位于同一个 slot, 可在 Lua 脚本中一起操作
+func (q *Queue) stream(queue string) string {
+	return q.opts.Prefix + "{" + queue + "}"
+}
+
+func (q *Queue) deadStream(queue string) string {
+	return q.stream(queue) + ":dead"
+}
+
+func (q *Queue) delayedSet(queue string) string {
+	return q.stream(queue) + ":delayed"
+}
+
+func (q *Queue) delayedData(queue string) string {
+	return q.stream(queue) + ":delayed:data"
+}
@@ -0,0 +1,208 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestQueue(t *testing.T, opts Options) *Queue {
+	s := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	opts.Block = 100 * time.Millisecond
+	opts.Backoff = func(int) time.Duration { return 10 * time.Millisecond }
+
+	return New(client, opts)
+}
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, Options{MaxRetries: 1})
+
+	var done, calls atomic.Int64
+
+	_ = q.Handle("ok", func(ctx context.Context, job *Job) error {
+		var v map[string]int
+		if err := job.Unmarshal(&v); err != nil || v["n"] != 1 {
+			t.Errorf("unexpected payload %s", job.Payload)
+		}
+
+		done.Add(1)
+		return nil
+	})
+
+	_ = q.Handle("flaky", func(ctx context.Context, job *Job) error {
+		calls.Add(1)
+		if job.Attempts < 2 {
+			return errors.New("flaky")
+		}
+
+		done.Add(1)
+		return nil
+	})
+
+	_ = q.Handle("broken", func(ctx context.Context, job *Job) error {
+		panic("broken")
+	})
+
+	// 启动前写入的任务也会被消费
+	if _, err := q.Enqueue(ctx, "ok", map[string]int{"n": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = q.Enqueue(ctx, "flaky", "x")
+	_, _ = q.Enqueue(ctx, "broken", "x")
+
+	waitFor(t, 5*time.Second, func() bool {
+		dead, _ := q.DeadLetters(ctx, "broken", 10)
+		return done.Load() == 2 && len(dead) == 1
+	})
+
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("flaky called %d times", n)
+	}
+
+	dead, _ := q.DeadLetters(ctx, "broken", 10)
+	if dead[0].Values["attempts"] != "2" || dead[0].Values["payload"] != "x" {
+		t.Fatalf("unexpected dead letter %v", dead[0].Values)
+	}
+
+	if err := q.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"ok", "flaky", "broken"} {
+		if n, _ := q.Pending(ctx, name); n != 0 {
+			t.Fatalf("%s has %d pending jobs", name, n)
+		}
+	}
+}
+
+func TestQueueStopWaitsForJobs(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, Options{})
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+
+	_ = q.Handle("slow", func(ctx context.Context, job *Job) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		finished.Store(true)
+
+		return nil
+	})
+
+	_ = q.Start(ctx)
+	_, _ = q.Enqueue(ctx, "slow", "x")
+
+	<-started
+
+	if err := q.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if !finished.Load() {
+		t.Fatal("Stop returned before job finished")
+	}
+}
+
+func TestDelayed(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, Options{})
+
+	keys := make(chan string, 1)
+	_ = q.Handle("order", func(ctx context.Context, job *Job) error {
+		keys <- job.Key
+		return nil
+	})
+
+	if _, err := q.EnqueueIn(ctx, "order", "timeout", 100*time.Millisecond, "order:1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := q.EnqueueIn(ctx, "order", "timeout", time.Second, "order:1"); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("expected ErrDuplicate, got %v", err)
+	}
+
+	id, _ := q.EnqueueIn(ctx, "order", "timeout", time.Hour)
+
+	jobs, err := q.Delayed(ctx, "order", 0, 10)
+	if err != nil || len(jobs) != 2 || jobs[0].ID != "order:1" || string(jobs[1].Payload) != "timeout" {
+		t.Fatalf("unexpected delayed jobs %+v, %v", jobs, err)
+	}
+
+	if ok, _ := q.Cancel(ctx, "order", id); !ok {
+		t.Fatal("cancel failed")
+	}
+
+	_ = q.Start(ctx)
+	defer func() { _ = q.Stop(ctx) }()
+
+	select {
+	case key := <-keys:
+		if key != "order:1" {
+			t.Fatalf("unexpected key %q", key)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("delayed job not executed")
+	}
+
+	if n, _ := q.DelayedCount(ctx, "order"); n != 0 {
+		t.Fatalf("%d delayed jobs left", n)
+	}
+}
+
+func TestClaimSkipsInflight(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, Options{ClaimIdle: 100 * time.Millisecond, Concurrency: 2})
+
+	var calls atomic.Int64
+
+	// 执行时间超过 ClaimIdle 及多个扫描周期
+	_ = q.Handle("long", func(ctx context.Context, job *Job) error {
+		calls.Add(1)
+		time.Sleep(2*scanInterval + 500*time.Millisecond)
+
+		return nil
+	})
+
+	_ = q.Start(ctx)
+	_, _ = q.Enqueue(ctx, "long", "x")
+
+	waitFor(t, 5*time.Second, func() bool {
+		n, _ := q.Pending(ctx, "long")
+		return calls.Load() > 0 && n == 0
+	})
+
+	if err := q.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("long job dispatched %d times", n)
+	}
+}
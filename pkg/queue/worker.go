@@ -0,0 +1,403 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// scanInterval 检查待重试及需要接管的任务的间隔
+const scanInterval = time.Second
+
+// promoteBatch 每次移入 stream 的延迟任务数
+const promoteBatch = 100
+
+type worker struct {
+	q       *Queue
+	name    string
+	stream  string
+	handler Handler
+
+	sem      chan struct{}
+	inflight sync.Map // id => struct{}
+}
+
+func newWorker(q *Queue, name string, handler Handler) *worker {
+	return &worker{
+		q:       q,
+		name:    name,
+		stream:  q.stream(name),
+		handler: handler,
+		sem:     make(chan struct{}, q.opts.Concurrency),
+	}
+}
+
+// read 拉取新任务
+func (w *worker) read(ctx context.Context) {
+	defer w.q.loops.Done()
+
+	for {
+		n := w.acquire(ctx)
+		if n == 0 {
+			return
+		}
+
+		res, err := w.q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    w.q.opts.Group,
+			Consumer: w.q.opts.Consumer,
+			Streams:  []string{w.stream, ">"},
+			Count:    int64(n),
+			Block:    w.q.opts.Block,
+		}).Result()
+		if err != nil {
+			w.release(n)
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !errors.Is(err, redis.Nil) {
+				slog.Error("queue-read", slog.String("queue", w.name), slog.String("error", err.Error()))
+				sleep(ctx, scanInterval)
+			}
+
+			continue
+		}
+
+		var msgs []redis.XMessage
+		if len(res) > 0 {
+			msgs = res[0].Messages
+		}
+
+		w.release(n - len(msgs))
+
+		for _, msg := range msgs {
+			w.dispatch(msg, 1)
+		}
+	}
+}
+
+// reclaim 移入到期的延迟任务, 重新执行到达重试时间的失败任务, 并接管崩溃消费者超时未确认的任务
+func (w *worker) reclaim(ctx context.Context) {
+	defer w.q.loops.Done()
+
+	ticker := time.NewTicker(scanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := w.promote(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("queue-promote", slog.String("queue", w.name), slog.String("error", err.Error()))
+		}
+
+		if err := w.retry(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("queue-retry", slog.String("queue", w.name), slog.String("error", err.Error()))
+		}
+
+		if err := w.autoClaim(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("queue-claim", slog.String("queue", w.name), slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (w *worker) promote(ctx context.Context) error {
+	for {
+		n, err := w.q.promote(ctx, w.name)
+		if err != nil || n < promoteBatch {
+			return err
+		}
+	}
+}
+
+// retry 执行失败的任务不确认, 留在当前消费者的 pending 列表中, 空闲时间超过 Backoff 后重新认领执行
+func (w *worker) retry(ctx context.Context) error {
+	pending, err := w.q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   w.stream,
+		Group:    w.q.opts.Group,
+		Consumer: w.q.opts.Consumer,
+		Start:    "-",
+		End:      "+",
+		Count:    int64(cap(w.sem)) * 2,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, p := range pending {
+		if _, ok := w.inflight.Load(p.ID); ok {
+			continue
+		}
+
+		backoff := w.q.opts.Backoff(int(p.RetryCount))
+		if p.Idle < backoff {
+			continue
+		}
+
+		// 拉取新任务时会占满空闲的并发数, 最多等待一个 Block 周期
+		if !w.acquireOne(ctx) {
+			return nil
+		}
+
+		msgs, err := w.q.client.XClaim(ctx, &redis.XClaimArgs{
+			Stream:   w.stream,
+			Group:    w.q.opts.Group,
+			Consumer: w.q.opts.Consumer,
+			MinIdle:  backoff,
+			Messages: []string{p.ID},
+		}).Result()
+		if err != nil || len(msgs) == 0 {
+			w.release(1)
+
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		w.dispatch(msgs[0], int(p.RetryCount)+1)
+	}
+
+	return nil
+}
+
+func (w *worker) autoClaim(ctx context.Context) error {
+	n := w.acquire(ctx)
+	if n == 0 {
+		return nil
+	}
+
+	msgs, _, err := w.q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   w.stream,
+		Group:    w.q.opts.Group,
+		Consumer: w.q.opts.Consumer,
+		MinIdle:  w.q.opts.ClaimIdle,
+		Start:    "0-0",
+		Count:    int64(n),
+	}).Result()
+	if err != nil {
+		w.release(n)
+		return err
+	}
+
+	w.release(n - len(msgs))
+
+	// 当前消费者执行时间超过 ClaimIdle 的任务同样会被认领, 执行中的任务不重复投递
+	claimed := msgs[:0]
+	for _, msg := range msgs {
+		if _, ok := w.inflight.Load(msg.ID); ok {
+			w.release(1)
+			continue
+		}
+
+		claimed = append(claimed, msg)
+	}
+	msgs = claimed
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	// 查询投递次数, XCLAIM/XAUTOCLAIM 会将投递次数加 1
+	cmds := make([]*redis.XPendingExtCmd, len(msgs))
+	_, _ = w.q.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, msg := range msgs {
+			cmds[i] = pipe.XPendingExt(ctx, &redis.XPendingExtArgs{
+				Stream: w.stream,
+				Group:  w.q.opts.Group,
+				Start:  msg.ID,
+				End:    msg.ID,
+				Count:  1,
+			})
+		}
+
+		return nil
+	})
+
+	for i, msg := range msgs {
+		attempts := 1
+		if res, err := cmds[i].Result(); err == nil && len(res) > 0 {
+			attempts = int(res[0].RetryCount)
+		}
+
+		slog.Warn(
+			"queue-claim",
+			slog.String("queue", w.name),
+			slog.String("id", msg.ID),
+			slog.Int("attempts", attempts),
+		)
+
+		w.dispatch(msg, attempts)
+	}
+
+	return nil
+}
+
+// dispatch 提交任务执行, 调用前需已占用一个并发数
+func (w *worker) dispatch(msg redis.XMessage, attempts int) {
+	job := parseJob(w.name, msg, attempts)
+
+	w.inflight.Store(job.ID, struct{}{})
+	w.q.jobs.Add(1)
+
+	task := func() {
+		defer func() {
+			w.inflight.Delete(job.ID)
+			w.release(1)
+			w.q.jobs.Done()
+		}()
+
+		w.process(job)
+	}
+
+	if err := w.q.opts.Submit(task); err != nil {
+		// 未执行的任务保留在 pending 列表中, 稍后重试
+		slog.Error("queue-submit", slog.String("queue", w.name), slog.String("id", job.ID), slog.String("error", err.Error()))
+
+		w.inflight.Delete(job.ID)
+		w.release(1)
+		w.q.jobs.Done()
+	}
+}
+
+func (w *worker) process(job *Job) {
+	start := time.Now()
+	err := w.run(job)
+
+	ctx := context.Background()
+
+	if err == nil {
+		if err = w.q.client.XAck(ctx, w.stream, w.q.opts.Group, job.ID).Err(); err != nil {
+			slog.Error("queue-ack", slog.String("queue", w.name), slog.String("id", job.ID), slog.String("error", err.Error()))
+		}
+
+		slog.Debug(
+			"queue-job",
+			slog.String("queue", w.name),
+			slog.String("id", job.ID),
+			slog.Int("attempts", job.Attempts),
+			slog.Int("took", int(time.Since(start).Milliseconds())),
+		)
+
+		return
+	}
+
+	fields := []any{
+		slog.String("queue", w.name),
+		slog.String("id", job.ID),
+		slog.Int("attempts", job.Attempts),
+		slog.Int("took", int(time.Since(start).Milliseconds())),
+		slog.String("error", err.Error()),
+	}
+
+	if job.Attempts <= w.q.opts.MaxRetries {
+		slog.Warn("queue-job", append(fields, slog.Duration("retry", w.q.opts.Backoff(job.Attempts)))...)
+		return
+	}
+
+	slog.Error("queue-job", append(fields, slog.Bool("dead", true))...)
+
+	if err = w.bury(ctx, job, err); err != nil {
+		slog.Error("queue-dead", slog.String("queue", w.name), slog.String("id", job.ID), slog.String("error", err.Error()))
+	}
+}
+
+// bury 将任务移入死信队列并从原队列删除
+func (w *worker) bury(ctx context.Context, job *Job, cause error) error {
+	_, err := w.q.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: w.q.deadStream(w.name),
+			Values: []any{
+				"id", job.ID,
+				"payload", job.Payload,
+				"created", job.CreatedAt.UnixMilli(),
+				"attempts", job.Attempts,
+				"error", cause.Error(),
+				"failed", time.Now().UnixMilli(),
+			},
+		})
+		pipe.XAck(ctx, w.stream, w.q.opts.Group, job.ID)
+		pipe.XDel(ctx, w.stream, job.ID)
+
+		return nil
+	})
+
+	return err
+}
+
+func (w *worker) run(job *Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			var buf [4096]byte
+			n := runtime.Stack(buf[:], false)
+			slog.Error("任务异常", slog.Any("error", p), slog.String("stack", string(buf[:n])))
+
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return w.handler(w.q.jobCtx, job)
+}
+
+// acquire 阻塞占用一个并发数, 并尽量占用其余空闲的并发数, ctx 结束时返回 0
+func (w *worker) acquire(ctx context.Context) int {
+	if !w.acquireOne(ctx) {
+		return 0
+	}
+
+	n := 1
+	for n < cap(w.sem) && w.tryAcquire() {
+		n++
+	}
+
+	return n
+}
+
+func (w *worker) acquireOne(ctx context.Context) bool {
+	select {
+	case w.sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *worker) tryAcquire() bool {
+	select {
+	case w.sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *worker) release(n int) {
+	for i := 0; i < n; i++ {
+		<-w.sem
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
@@ -0,0 +1,85 @@
+// proxygen 生成 pkg/redis/proxy_gen.go, 为 Client 实现 redis.UniversalClient 的转发方法
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 在 proxy.go 中手动实现的方法
+var skip = map[string]bool{
+	"AddHook": true,
+	"Close":   true,
+}
+
+func main() {
+	typ := reflect.TypeOf((*redis.UniversalClient)(nil)).Elem()
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by internal/proxygen; DO NOT EDIT.\n\n")
+	buf.WriteString("package redis\n\n")
+	buf.WriteString("import (\n\t\"context\"\n\t\"time\"\n\n\t\"github.com/redis/go-redis/v9\"\n)\n\n")
+
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		if skip[m.Name] {
+			continue
+		}
+
+		ft := m.Type
+
+		params := make([]string, ft.NumIn())
+		args := make([]string, ft.NumIn())
+
+		for j := 0; j < ft.NumIn(); j++ {
+			t := ft.In(j)
+			name := fmt.Sprintf("a%d", j)
+
+			if ft.IsVariadic() && j == ft.NumIn()-1 {
+				params[j] = name + " ..." + typeName(t.Elem())
+				args[j] = name + "..."
+			} else {
+				params[j] = name + " " + typeName(t)
+				args[j] = name
+			}
+		}
+
+		results := make([]string, ft.NumOut())
+		for j := 0; j < ft.NumOut(); j++ {
+			results[j] = typeName(ft.Out(j))
+		}
+
+		ret := strings.Join(results, ", ")
+		if len(results) > 1 {
+			ret = "(" + ret + ")"
+		}
+
+		call := fmt.Sprintf("c.client().%s(%s)", m.Name, strings.Join(args, ", "))
+		if len(results) > 0 {
+			call = "return " + call
+		}
+
+		fmt.Fprintf(&buf, "\nfunc (c *Client) %s(%s) %s {\n\t%s\n}\n", m.Name, strings.Join(params, ", "), ret, call)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err = os.WriteFile("proxy_gen.go", src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func typeName(t reflect.Type) string {
+	return strings.ReplaceAll(t.String(), "interface {}", "any")
+}
@@ -3,11 +3,15 @@ package redis
 import (
 	"context"
 	"fmt"
-	"log/slog"
+
+	"github.com/yrbb/rain/pkg/logger"
 )
 
+// redisLogger 可通过 logger.SetNamedLevel("redis", ...) 单独设置级别
+var redisLogger = logger.Named("redis")
+
 type Logger struct{}
 
 func (l *Logger) Printf(ctx context.Context, format string, v ...interface{}) {
-	slog.Info(fmt.Sprintf(format, v...))
+	redisLogger.Info(fmt.Sprintf(format, v...))
 }
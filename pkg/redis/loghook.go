@@ -2,18 +2,26 @@ package redis
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	maxLogArgs   = 16 // 单条命令最多记录的参数个数
+	maxLogArgLen = 64 // 单个参数最多记录的长度
+)
+
 var _ redis.Hook = &LogHook{}
 
 type LogHook struct {
-	Name  string
-	Debug bool
+	Name          string
+	Debug         bool
+	SlowThreshold time.Duration // 超过该耗时的命令以 warn 级别记录, 0 表示不记录
 }
 
 func (h *LogHook) DialHook(next redis.DialHook) redis.DialHook {
@@ -27,30 +35,149 @@ func (h *LogHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
 		start := time.Now()
 		err := next(ctx, cmd)
 
-		if err != nil && err != redis.Nil {
-			slog.Error(
-				"redis-query",
-				slog.String("name", h.Name),
-				slog.String("cmd", cmd.Name()),
-				slog.Any("args", cmd.Args()),
-				slog.Int("took", int(time.Since(start).Milliseconds())),
-				slog.String("error", err.Error()),
-			)
-		} else if h.Debug {
-			slog.Debug(
-				"redis-query",
-				slog.String("name", h.Name),
-				slog.String("cmd", cmd.Name()),
-				slog.Any("args", cmd.Args()),
-				slog.Int("took", int(time.Since(start).Milliseconds())),
-			)
-		}
+		h.log(
+			"redis-query",
+			time.Since(start),
+			err,
+			slog.String("cmd", cmd.Name()),
+			slog.Any("args", logArgs(cmd)),
+		)
 
 		return err
 	}
 }
+
 func (h *LogHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
 	return func(ctx context.Context, cmds []redis.Cmder) error {
-		return next(ctx, cmds)
+		start := time.Now()
+		err := next(ctx, cmds)
+
+		// TxPipeline 的命令以 MULTI 开始, EXEC 结束
+		tx := len(cmds) > 1 && cmds[0].Name() == "multi" && cmds[len(cmds)-1].Name() == "exec"
+		if tx {
+			cmds = cmds[1 : len(cmds)-1]
+		}
+
+		list := make([]string, len(cmds))
+		errs := map[int]string{}
+
+		for i, cmd := range cmds {
+			list[i] = joinArgs(logArgs(cmd))
+
+			if e := cmd.Err(); e != nil && e != redis.Nil {
+				errs[i] = e.Error()
+			}
+		}
+
+		attrs := []any{
+			slog.Bool("tx", tx),
+			slog.Int("count", len(cmds)),
+			slog.Any("cmds", list),
+		}
+
+		if len(errs) > 0 {
+			attrs = append(attrs, slog.Any("errors", errs))
+		}
+
+		h.log("redis-pipeline", time.Since(start), err, attrs...)
+
+		return err
+	}
+}
+
+func (h *LogHook) log(msg string, took time.Duration, err error, attrs ...any) {
+	failed := err != nil && err != redis.Nil
+	slow := h.SlowThreshold > 0 && took >= h.SlowThreshold
+
+	if !failed && !slow && !h.Debug {
+		return
+	}
+
+	fields := append([]any{slog.String("name", h.Name)}, attrs...)
+	fields = append(fields, slog.Int("took", int(took.Milliseconds())))
+
+	switch {
+	case failed:
+		redisLogger.Error(msg, append(fields, slog.String("error", err.Error()))...)
+	case slow:
+		redisLogger.Warn(msg, append(fields, slog.Bool("slow", true))...)
+	default:
+		redisLogger.Debug(msg, fields...)
+	}
+}
+
+// logArgs 返回用于日志的命令参数, 隐藏认证信息并截断过长的参数
+func logArgs(cmd redis.Cmder) []any {
+	args := cmd.Args()
+
+	n := len(args)
+	if n > maxLogArgs {
+		n = maxLogArgs
+	}
+
+	res := make([]any, n, n+1)
+	for i := 0; i < n; i++ {
+		res[i] = truncateArg(args[i])
+	}
+
+	if len(args) > maxLogArgs {
+		res = append(res, fmt.Sprintf("...(%d more)", len(args)-maxLogArgs))
+	}
+
+	switch strings.ToLower(cmd.Name()) {
+	case "auth":
+		maskArgs(res, 1, n)
+	case "hello":
+		// HELLO protover AUTH username password
+		for i := 2; i < n; i++ {
+			if s, ok := res[i].(string); ok && strings.EqualFold(s, "auth") {
+				maskArgs(res, i+1, i+3)
+				break
+			}
+		}
+	case "config":
+		// CONFIG SET requirepass/masterauth value
+		if n > 3 {
+			if s, ok := res[2].(string); ok && (strings.EqualFold(s, "requirepass") || strings.EqualFold(s, "masterauth")) {
+				maskArgs(res, 3, n)
+			}
+		}
+	}
+
+	return res
+}
+
+// joinArgs 以空格连接命令参数, eg: set k v
+func joinArgs(args []any) string {
+	list := make([]string, len(args))
+	for i, arg := range args {
+		list[i] = fmt.Sprint(arg)
 	}
+
+	return strings.Join(list, " ")
+}
+
+func maskArgs(args []any, from, to int) {
+	for i := from; i < to && i < len(args); i++ {
+		args[i] = "*"
+	}
+}
+
+func truncateArg(arg any) any {
+	var s string
+
+	switch v := arg.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return arg
+	}
+
+	if len(s) <= maxLogArgLen {
+		return s
+	}
+
+	return fmt.Sprintf("%s...(%d bytes)", s[:maxLogArgLen], len(s))
 }
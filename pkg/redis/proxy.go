@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:generate go run ./internal/proxygen
+
+var _ redis.UniversalClient = &Client{}
+
+// Client 为稳定的 Redis 句柄, 配置热更新时替换底层客户端, 调用方持有的句柄继续可用.
+// Pipeline, PubSub 等对象绑定在获取时的底层客户端上, 热更新后需要重新获取
+type Client struct {
+	cur atomic.Pointer[redis.UniversalClient]
+
+	mu    sync.Mutex
+	hooks []redis.Hook
+}
+
+func wrapClient(rdb redis.UniversalClient) *Client {
+	c := &Client{}
+	c.cur.Store(&rdb)
+
+	return c
+}
+
+func (c *Client) client() redis.UniversalClient {
+	return *c.cur.Load()
+}
+
+// Unwrap 返回当前的底层客户端
+func (c *Client) Unwrap() redis.UniversalClient {
+	return c.client()
+}
+
+// AddHook 添加到当前及热更新后的底层客户端
+func (c *Client) AddHook(hook redis.Hook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hooks = append(c.hooks, hook)
+	c.client().AddHook(hook)
+}
+
+func (c *Client) Close() error {
+	return c.client().Close()
+}
+
+// swap 替换底层客户端并返回旧的客户端
+func (c *Client) swap(rdb redis.UniversalClient) redis.UniversalClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, hook := range c.hooks {
+		rdb.AddHook(hook)
+	}
+
+	return *c.cur.Swap(&rdb)
+}
@@ -0,0 +1,1654 @@
+// Code generated by internal/proxygen; DO NOT EDIT.
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func (c *Client) ACLDryRun(a0 context.Context, a1 string, a2 ...any) *redis.StringCmd {
+	return c.client().ACLDryRun(a0, a1, a2...)
+}
+
+func (c *Client) ACLLog(a0 context.Context, a1 int64) *redis.ACLLogCmd {
+	return c.client().ACLLog(a0, a1)
+}
+
+func (c *Client) ACLLogReset(a0 context.Context) *redis.StatusCmd {
+	return c.client().ACLLogReset(a0)
+}
+
+func (c *Client) Append(a0 context.Context, a1 string, a2 string) *redis.IntCmd {
+	return c.client().Append(a0, a1, a2)
+}
+
+func (c *Client) BFAdd(a0 context.Context, a1 string, a2 any) *redis.BoolCmd {
+	return c.client().BFAdd(a0, a1, a2)
+}
+
+func (c *Client) BFCard(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().BFCard(a0, a1)
+}
+
+func (c *Client) BFExists(a0 context.Context, a1 string, a2 any) *redis.BoolCmd {
+	return c.client().BFExists(a0, a1, a2)
+}
+
+func (c *Client) BFInfo(a0 context.Context, a1 string) *redis.BFInfoCmd {
+	return c.client().BFInfo(a0, a1)
+}
+
+func (c *Client) BFInfoArg(a0 context.Context, a1 string, a2 string) *redis.BFInfoCmd {
+	return c.client().BFInfoArg(a0, a1, a2)
+}
+
+func (c *Client) BFInfoCapacity(a0 context.Context, a1 string) *redis.BFInfoCmd {
+	return c.client().BFInfoCapacity(a0, a1)
+}
+
+func (c *Client) BFInfoExpansion(a0 context.Context, a1 string) *redis.BFInfoCmd {
+	return c.client().BFInfoExpansion(a0, a1)
+}
+
+func (c *Client) BFInfoFilters(a0 context.Context, a1 string) *redis.BFInfoCmd {
+	return c.client().BFInfoFilters(a0, a1)
+}
+
+func (c *Client) BFInfoItems(a0 context.Context, a1 string) *redis.BFInfoCmd {
+	return c.client().BFInfoItems(a0, a1)
+}
+
+func (c *Client) BFInfoSize(a0 context.Context, a1 string) *redis.BFInfoCmd {
+	return c.client().BFInfoSize(a0, a1)
+}
+
+func (c *Client) BFInsert(a0 context.Context, a1 string, a2 *redis.BFInsertOptions, a3 ...any) *redis.BoolSliceCmd {
+	return c.client().BFInsert(a0, a1, a2, a3...)
+}
+
+func (c *Client) BFLoadChunk(a0 context.Context, a1 string, a2 int64, a3 any) *redis.StatusCmd {
+	return c.client().BFLoadChunk(a0, a1, a2, a3)
+}
+
+func (c *Client) BFMAdd(a0 context.Context, a1 string, a2 ...any) *redis.BoolSliceCmd {
+	return c.client().BFMAdd(a0, a1, a2...)
+}
+
+func (c *Client) BFMExists(a0 context.Context, a1 string, a2 ...any) *redis.BoolSliceCmd {
+	return c.client().BFMExists(a0, a1, a2...)
+}
+
+func (c *Client) BFReserve(a0 context.Context, a1 string, a2 float64, a3 int64) *redis.StatusCmd {
+	return c.client().BFReserve(a0, a1, a2, a3)
+}
+
+func (c *Client) BFReserveArgs(a0 context.Context, a1 string, a2 *redis.BFReserveOptions) *redis.StatusCmd {
+	return c.client().BFReserveArgs(a0, a1, a2)
+}
+
+func (c *Client) BFReserveExpansion(a0 context.Context, a1 string, a2 float64, a3 int64, a4 int64) *redis.StatusCmd {
+	return c.client().BFReserveExpansion(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) BFReserveNonScaling(a0 context.Context, a1 string, a2 float64, a3 int64) *redis.StatusCmd {
+	return c.client().BFReserveNonScaling(a0, a1, a2, a3)
+}
+
+func (c *Client) BFScanDump(a0 context.Context, a1 string, a2 int64) *redis.ScanDumpCmd {
+	return c.client().BFScanDump(a0, a1, a2)
+}
+
+func (c *Client) BLMPop(a0 context.Context, a1 time.Duration, a2 string, a3 int64, a4 ...string) *redis.KeyValuesCmd {
+	return c.client().BLMPop(a0, a1, a2, a3, a4...)
+}
+
+func (c *Client) BLMove(a0 context.Context, a1 string, a2 string, a3 string, a4 string, a5 time.Duration) *redis.StringCmd {
+	return c.client().BLMove(a0, a1, a2, a3, a4, a5)
+}
+
+func (c *Client) BLPop(a0 context.Context, a1 time.Duration, a2 ...string) *redis.StringSliceCmd {
+	return c.client().BLPop(a0, a1, a2...)
+}
+
+func (c *Client) BRPop(a0 context.Context, a1 time.Duration, a2 ...string) *redis.StringSliceCmd {
+	return c.client().BRPop(a0, a1, a2...)
+}
+
+func (c *Client) BRPopLPush(a0 context.Context, a1 string, a2 string, a3 time.Duration) *redis.StringCmd {
+	return c.client().BRPopLPush(a0, a1, a2, a3)
+}
+
+func (c *Client) BZMPop(a0 context.Context, a1 time.Duration, a2 string, a3 int64, a4 ...string) *redis.ZSliceWithKeyCmd {
+	return c.client().BZMPop(a0, a1, a2, a3, a4...)
+}
+
+func (c *Client) BZPopMax(a0 context.Context, a1 time.Duration, a2 ...string) *redis.ZWithKeyCmd {
+	return c.client().BZPopMax(a0, a1, a2...)
+}
+
+func (c *Client) BZPopMin(a0 context.Context, a1 time.Duration, a2 ...string) *redis.ZWithKeyCmd {
+	return c.client().BZPopMin(a0, a1, a2...)
+}
+
+func (c *Client) BgRewriteAOF(a0 context.Context) *redis.StatusCmd {
+	return c.client().BgRewriteAOF(a0)
+}
+
+func (c *Client) BgSave(a0 context.Context) *redis.StatusCmd {
+	return c.client().BgSave(a0)
+}
+
+func (c *Client) BitCount(a0 context.Context, a1 string, a2 *redis.BitCount) *redis.IntCmd {
+	return c.client().BitCount(a0, a1, a2)
+}
+
+func (c *Client) BitField(a0 context.Context, a1 string, a2 ...any) *redis.IntSliceCmd {
+	return c.client().BitField(a0, a1, a2...)
+}
+
+func (c *Client) BitOpAnd(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().BitOpAnd(a0, a1, a2...)
+}
+
+func (c *Client) BitOpNot(a0 context.Context, a1 string, a2 string) *redis.IntCmd {
+	return c.client().BitOpNot(a0, a1, a2)
+}
+
+func (c *Client) BitOpOr(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().BitOpOr(a0, a1, a2...)
+}
+
+func (c *Client) BitOpXor(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().BitOpXor(a0, a1, a2...)
+}
+
+func (c *Client) BitPos(a0 context.Context, a1 string, a2 int64, a3 ...int64) *redis.IntCmd {
+	return c.client().BitPos(a0, a1, a2, a3...)
+}
+
+func (c *Client) BitPosSpan(a0 context.Context, a1 string, a2 int8, a3 int64, a4 int64, a5 string) *redis.IntCmd {
+	return c.client().BitPosSpan(a0, a1, a2, a3, a4, a5)
+}
+
+func (c *Client) CFAdd(a0 context.Context, a1 string, a2 any) *redis.BoolCmd {
+	return c.client().CFAdd(a0, a1, a2)
+}
+
+func (c *Client) CFAddNX(a0 context.Context, a1 string, a2 any) *redis.BoolCmd {
+	return c.client().CFAddNX(a0, a1, a2)
+}
+
+func (c *Client) CFCount(a0 context.Context, a1 string, a2 any) *redis.IntCmd {
+	return c.client().CFCount(a0, a1, a2)
+}
+
+func (c *Client) CFDel(a0 context.Context, a1 string, a2 any) *redis.BoolCmd {
+	return c.client().CFDel(a0, a1, a2)
+}
+
+func (c *Client) CFExists(a0 context.Context, a1 string, a2 any) *redis.BoolCmd {
+	return c.client().CFExists(a0, a1, a2)
+}
+
+func (c *Client) CFInfo(a0 context.Context, a1 string) *redis.CFInfoCmd {
+	return c.client().CFInfo(a0, a1)
+}
+
+func (c *Client) CFInsert(a0 context.Context, a1 string, a2 *redis.CFInsertOptions, a3 ...any) *redis.BoolSliceCmd {
+	return c.client().CFInsert(a0, a1, a2, a3...)
+}
+
+func (c *Client) CFInsertNX(a0 context.Context, a1 string, a2 *redis.CFInsertOptions, a3 ...any) *redis.IntSliceCmd {
+	return c.client().CFInsertNX(a0, a1, a2, a3...)
+}
+
+func (c *Client) CFLoadChunk(a0 context.Context, a1 string, a2 int64, a3 any) *redis.StatusCmd {
+	return c.client().CFLoadChunk(a0, a1, a2, a3)
+}
+
+func (c *Client) CFMExists(a0 context.Context, a1 string, a2 ...any) *redis.BoolSliceCmd {
+	return c.client().CFMExists(a0, a1, a2...)
+}
+
+func (c *Client) CFReserve(a0 context.Context, a1 string, a2 int64) *redis.StatusCmd {
+	return c.client().CFReserve(a0, a1, a2)
+}
+
+func (c *Client) CFReserveArgs(a0 context.Context, a1 string, a2 *redis.CFReserveOptions) *redis.StatusCmd {
+	return c.client().CFReserveArgs(a0, a1, a2)
+}
+
+func (c *Client) CFReserveBucketSize(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StatusCmd {
+	return c.client().CFReserveBucketSize(a0, a1, a2, a3)
+}
+
+func (c *Client) CFReserveExpansion(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StatusCmd {
+	return c.client().CFReserveExpansion(a0, a1, a2, a3)
+}
+
+func (c *Client) CFReserveMaxIterations(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StatusCmd {
+	return c.client().CFReserveMaxIterations(a0, a1, a2, a3)
+}
+
+func (c *Client) CFScanDump(a0 context.Context, a1 string, a2 int64) *redis.ScanDumpCmd {
+	return c.client().CFScanDump(a0, a1, a2)
+}
+
+func (c *Client) CMSIncrBy(a0 context.Context, a1 string, a2 ...any) *redis.IntSliceCmd {
+	return c.client().CMSIncrBy(a0, a1, a2...)
+}
+
+func (c *Client) CMSInfo(a0 context.Context, a1 string) *redis.CMSInfoCmd {
+	return c.client().CMSInfo(a0, a1)
+}
+
+func (c *Client) CMSInitByDim(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StatusCmd {
+	return c.client().CMSInitByDim(a0, a1, a2, a3)
+}
+
+func (c *Client) CMSInitByProb(a0 context.Context, a1 string, a2 float64, a3 float64) *redis.StatusCmd {
+	return c.client().CMSInitByProb(a0, a1, a2, a3)
+}
+
+func (c *Client) CMSMerge(a0 context.Context, a1 string, a2 ...string) *redis.StatusCmd {
+	return c.client().CMSMerge(a0, a1, a2...)
+}
+
+func (c *Client) CMSMergeWithWeight(a0 context.Context, a1 string, a2 map[string]int64) *redis.StatusCmd {
+	return c.client().CMSMergeWithWeight(a0, a1, a2)
+}
+
+func (c *Client) CMSQuery(a0 context.Context, a1 string, a2 ...any) *redis.IntSliceCmd {
+	return c.client().CMSQuery(a0, a1, a2...)
+}
+
+func (c *Client) ClientGetName(a0 context.Context) *redis.StringCmd {
+	return c.client().ClientGetName(a0)
+}
+
+func (c *Client) ClientID(a0 context.Context) *redis.IntCmd {
+	return c.client().ClientID(a0)
+}
+
+func (c *Client) ClientInfo(a0 context.Context) *redis.ClientInfoCmd {
+	return c.client().ClientInfo(a0)
+}
+
+func (c *Client) ClientKill(a0 context.Context, a1 string) *redis.StatusCmd {
+	return c.client().ClientKill(a0, a1)
+}
+
+func (c *Client) ClientKillByFilter(a0 context.Context, a1 ...string) *redis.IntCmd {
+	return c.client().ClientKillByFilter(a0, a1...)
+}
+
+func (c *Client) ClientList(a0 context.Context) *redis.StringCmd {
+	return c.client().ClientList(a0)
+}
+
+func (c *Client) ClientPause(a0 context.Context, a1 time.Duration) *redis.BoolCmd {
+	return c.client().ClientPause(a0, a1)
+}
+
+func (c *Client) ClientUnblock(a0 context.Context, a1 int64) *redis.IntCmd {
+	return c.client().ClientUnblock(a0, a1)
+}
+
+func (c *Client) ClientUnblockWithError(a0 context.Context, a1 int64) *redis.IntCmd {
+	return c.client().ClientUnblockWithError(a0, a1)
+}
+
+func (c *Client) ClientUnpause(a0 context.Context) *redis.BoolCmd {
+	return c.client().ClientUnpause(a0)
+}
+
+func (c *Client) ClusterAddSlots(a0 context.Context, a1 ...int) *redis.StatusCmd {
+	return c.client().ClusterAddSlots(a0, a1...)
+}
+
+func (c *Client) ClusterAddSlotsRange(a0 context.Context, a1 int, a2 int) *redis.StatusCmd {
+	return c.client().ClusterAddSlotsRange(a0, a1, a2)
+}
+
+func (c *Client) ClusterCountFailureReports(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().ClusterCountFailureReports(a0, a1)
+}
+
+func (c *Client) ClusterCountKeysInSlot(a0 context.Context, a1 int) *redis.IntCmd {
+	return c.client().ClusterCountKeysInSlot(a0, a1)
+}
+
+func (c *Client) ClusterDelSlots(a0 context.Context, a1 ...int) *redis.StatusCmd {
+	return c.client().ClusterDelSlots(a0, a1...)
+}
+
+func (c *Client) ClusterDelSlotsRange(a0 context.Context, a1 int, a2 int) *redis.StatusCmd {
+	return c.client().ClusterDelSlotsRange(a0, a1, a2)
+}
+
+func (c *Client) ClusterFailover(a0 context.Context) *redis.StatusCmd {
+	return c.client().ClusterFailover(a0)
+}
+
+func (c *Client) ClusterForget(a0 context.Context, a1 string) *redis.StatusCmd {
+	return c.client().ClusterForget(a0, a1)
+}
+
+func (c *Client) ClusterGetKeysInSlot(a0 context.Context, a1 int, a2 int) *redis.StringSliceCmd {
+	return c.client().ClusterGetKeysInSlot(a0, a1, a2)
+}
+
+func (c *Client) ClusterInfo(a0 context.Context) *redis.StringCmd {
+	return c.client().ClusterInfo(a0)
+}
+
+func (c *Client) ClusterKeySlot(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().ClusterKeySlot(a0, a1)
+}
+
+func (c *Client) ClusterLinks(a0 context.Context) *redis.ClusterLinksCmd {
+	return c.client().ClusterLinks(a0)
+}
+
+func (c *Client) ClusterMeet(a0 context.Context, a1 string, a2 string) *redis.StatusCmd {
+	return c.client().ClusterMeet(a0, a1, a2)
+}
+
+func (c *Client) ClusterMyShardID(a0 context.Context) *redis.StringCmd {
+	return c.client().ClusterMyShardID(a0)
+}
+
+func (c *Client) ClusterNodes(a0 context.Context) *redis.StringCmd {
+	return c.client().ClusterNodes(a0)
+}
+
+func (c *Client) ClusterReplicate(a0 context.Context, a1 string) *redis.StatusCmd {
+	return c.client().ClusterReplicate(a0, a1)
+}
+
+func (c *Client) ClusterResetHard(a0 context.Context) *redis.StatusCmd {
+	return c.client().ClusterResetHard(a0)
+}
+
+func (c *Client) ClusterResetSoft(a0 context.Context) *redis.StatusCmd {
+	return c.client().ClusterResetSoft(a0)
+}
+
+func (c *Client) ClusterSaveConfig(a0 context.Context) *redis.StatusCmd {
+	return c.client().ClusterSaveConfig(a0)
+}
+
+func (c *Client) ClusterShards(a0 context.Context) *redis.ClusterShardsCmd {
+	return c.client().ClusterShards(a0)
+}
+
+func (c *Client) ClusterSlaves(a0 context.Context, a1 string) *redis.StringSliceCmd {
+	return c.client().ClusterSlaves(a0, a1)
+}
+
+func (c *Client) ClusterSlots(a0 context.Context) *redis.ClusterSlotsCmd {
+	return c.client().ClusterSlots(a0)
+}
+
+func (c *Client) Command(a0 context.Context) *redis.CommandsInfoCmd {
+	return c.client().Command(a0)
+}
+
+func (c *Client) CommandGetKeys(a0 context.Context, a1 ...any) *redis.StringSliceCmd {
+	return c.client().CommandGetKeys(a0, a1...)
+}
+
+func (c *Client) CommandGetKeysAndFlags(a0 context.Context, a1 ...any) *redis.KeyFlagsCmd {
+	return c.client().CommandGetKeysAndFlags(a0, a1...)
+}
+
+func (c *Client) CommandList(a0 context.Context, a1 *redis.FilterBy) *redis.StringSliceCmd {
+	return c.client().CommandList(a0, a1)
+}
+
+func (c *Client) ConfigGet(a0 context.Context, a1 string) *redis.MapStringStringCmd {
+	return c.client().ConfigGet(a0, a1)
+}
+
+func (c *Client) ConfigResetStat(a0 context.Context) *redis.StatusCmd {
+	return c.client().ConfigResetStat(a0)
+}
+
+func (c *Client) ConfigRewrite(a0 context.Context) *redis.StatusCmd {
+	return c.client().ConfigRewrite(a0)
+}
+
+func (c *Client) ConfigSet(a0 context.Context, a1 string, a2 string) *redis.StatusCmd {
+	return c.client().ConfigSet(a0, a1, a2)
+}
+
+func (c *Client) Copy(a0 context.Context, a1 string, a2 string, a3 int, a4 bool) *redis.IntCmd {
+	return c.client().Copy(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) DBSize(a0 context.Context) *redis.IntCmd {
+	return c.client().DBSize(a0)
+}
+
+func (c *Client) DebugObject(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().DebugObject(a0, a1)
+}
+
+func (c *Client) Decr(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().Decr(a0, a1)
+}
+
+func (c *Client) DecrBy(a0 context.Context, a1 string, a2 int64) *redis.IntCmd {
+	return c.client().DecrBy(a0, a1, a2)
+}
+
+func (c *Client) Del(a0 context.Context, a1 ...string) *redis.IntCmd {
+	return c.client().Del(a0, a1...)
+}
+
+func (c *Client) Do(a0 context.Context, a1 ...any) *redis.Cmd {
+	return c.client().Do(a0, a1...)
+}
+
+func (c *Client) Dump(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().Dump(a0, a1)
+}
+
+func (c *Client) Echo(a0 context.Context, a1 any) *redis.StringCmd {
+	return c.client().Echo(a0, a1)
+}
+
+func (c *Client) Eval(a0 context.Context, a1 string, a2 []string, a3 ...any) *redis.Cmd {
+	return c.client().Eval(a0, a1, a2, a3...)
+}
+
+func (c *Client) EvalRO(a0 context.Context, a1 string, a2 []string, a3 ...any) *redis.Cmd {
+	return c.client().EvalRO(a0, a1, a2, a3...)
+}
+
+func (c *Client) EvalSha(a0 context.Context, a1 string, a2 []string, a3 ...any) *redis.Cmd {
+	return c.client().EvalSha(a0, a1, a2, a3...)
+}
+
+func (c *Client) EvalShaRO(a0 context.Context, a1 string, a2 []string, a3 ...any) *redis.Cmd {
+	return c.client().EvalShaRO(a0, a1, a2, a3...)
+}
+
+func (c *Client) Exists(a0 context.Context, a1 ...string) *redis.IntCmd {
+	return c.client().Exists(a0, a1...)
+}
+
+func (c *Client) Expire(a0 context.Context, a1 string, a2 time.Duration) *redis.BoolCmd {
+	return c.client().Expire(a0, a1, a2)
+}
+
+func (c *Client) ExpireAt(a0 context.Context, a1 string, a2 time.Time) *redis.BoolCmd {
+	return c.client().ExpireAt(a0, a1, a2)
+}
+
+func (c *Client) ExpireGT(a0 context.Context, a1 string, a2 time.Duration) *redis.BoolCmd {
+	return c.client().ExpireGT(a0, a1, a2)
+}
+
+func (c *Client) ExpireLT(a0 context.Context, a1 string, a2 time.Duration) *redis.BoolCmd {
+	return c.client().ExpireLT(a0, a1, a2)
+}
+
+func (c *Client) ExpireNX(a0 context.Context, a1 string, a2 time.Duration) *redis.BoolCmd {
+	return c.client().ExpireNX(a0, a1, a2)
+}
+
+func (c *Client) ExpireTime(a0 context.Context, a1 string) *redis.DurationCmd {
+	return c.client().ExpireTime(a0, a1)
+}
+
+func (c *Client) ExpireXX(a0 context.Context, a1 string, a2 time.Duration) *redis.BoolCmd {
+	return c.client().ExpireXX(a0, a1, a2)
+}
+
+func (c *Client) FCall(a0 context.Context, a1 string, a2 []string, a3 ...any) *redis.Cmd {
+	return c.client().FCall(a0, a1, a2, a3...)
+}
+
+func (c *Client) FCallRO(a0 context.Context, a1 string, a2 []string, a3 ...any) *redis.Cmd {
+	return c.client().FCallRO(a0, a1, a2, a3...)
+}
+
+func (c *Client) FCallRo(a0 context.Context, a1 string, a2 []string, a3 ...any) *redis.Cmd {
+	return c.client().FCallRo(a0, a1, a2, a3...)
+}
+
+func (c *Client) FlushAll(a0 context.Context) *redis.StatusCmd {
+	return c.client().FlushAll(a0)
+}
+
+func (c *Client) FlushAllAsync(a0 context.Context) *redis.StatusCmd {
+	return c.client().FlushAllAsync(a0)
+}
+
+func (c *Client) FlushDB(a0 context.Context) *redis.StatusCmd {
+	return c.client().FlushDB(a0)
+}
+
+func (c *Client) FlushDBAsync(a0 context.Context) *redis.StatusCmd {
+	return c.client().FlushDBAsync(a0)
+}
+
+func (c *Client) FunctionDelete(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().FunctionDelete(a0, a1)
+}
+
+func (c *Client) FunctionDump(a0 context.Context) *redis.StringCmd {
+	return c.client().FunctionDump(a0)
+}
+
+func (c *Client) FunctionFlush(a0 context.Context) *redis.StringCmd {
+	return c.client().FunctionFlush(a0)
+}
+
+func (c *Client) FunctionFlushAsync(a0 context.Context) *redis.StringCmd {
+	return c.client().FunctionFlushAsync(a0)
+}
+
+func (c *Client) FunctionKill(a0 context.Context) *redis.StringCmd {
+	return c.client().FunctionKill(a0)
+}
+
+func (c *Client) FunctionList(a0 context.Context, a1 redis.FunctionListQuery) *redis.FunctionListCmd {
+	return c.client().FunctionList(a0, a1)
+}
+
+func (c *Client) FunctionLoad(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().FunctionLoad(a0, a1)
+}
+
+func (c *Client) FunctionLoadReplace(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().FunctionLoadReplace(a0, a1)
+}
+
+func (c *Client) FunctionRestore(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().FunctionRestore(a0, a1)
+}
+
+func (c *Client) FunctionStats(a0 context.Context) *redis.FunctionStatsCmd {
+	return c.client().FunctionStats(a0)
+}
+
+func (c *Client) GeoAdd(a0 context.Context, a1 string, a2 ...*redis.GeoLocation) *redis.IntCmd {
+	return c.client().GeoAdd(a0, a1, a2...)
+}
+
+func (c *Client) GeoDist(a0 context.Context, a1 string, a2 string, a3 string, a4 string) *redis.FloatCmd {
+	return c.client().GeoDist(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) GeoHash(a0 context.Context, a1 string, a2 ...string) *redis.StringSliceCmd {
+	return c.client().GeoHash(a0, a1, a2...)
+}
+
+func (c *Client) GeoPos(a0 context.Context, a1 string, a2 ...string) *redis.GeoPosCmd {
+	return c.client().GeoPos(a0, a1, a2...)
+}
+
+func (c *Client) GeoRadius(a0 context.Context, a1 string, a2 float64, a3 float64, a4 *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	return c.client().GeoRadius(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) GeoRadiusByMember(a0 context.Context, a1 string, a2 string, a3 *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	return c.client().GeoRadiusByMember(a0, a1, a2, a3)
+}
+
+func (c *Client) GeoRadiusByMemberStore(a0 context.Context, a1 string, a2 string, a3 *redis.GeoRadiusQuery) *redis.IntCmd {
+	return c.client().GeoRadiusByMemberStore(a0, a1, a2, a3)
+}
+
+func (c *Client) GeoRadiusStore(a0 context.Context, a1 string, a2 float64, a3 float64, a4 *redis.GeoRadiusQuery) *redis.IntCmd {
+	return c.client().GeoRadiusStore(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) GeoSearch(a0 context.Context, a1 string, a2 *redis.GeoSearchQuery) *redis.StringSliceCmd {
+	return c.client().GeoSearch(a0, a1, a2)
+}
+
+func (c *Client) GeoSearchLocation(a0 context.Context, a1 string, a2 *redis.GeoSearchLocationQuery) *redis.GeoSearchLocationCmd {
+	return c.client().GeoSearchLocation(a0, a1, a2)
+}
+
+func (c *Client) GeoSearchStore(a0 context.Context, a1 string, a2 string, a3 *redis.GeoSearchStoreQuery) *redis.IntCmd {
+	return c.client().GeoSearchStore(a0, a1, a2, a3)
+}
+
+func (c *Client) Get(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().Get(a0, a1)
+}
+
+func (c *Client) GetBit(a0 context.Context, a1 string, a2 int64) *redis.IntCmd {
+	return c.client().GetBit(a0, a1, a2)
+}
+
+func (c *Client) GetDel(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().GetDel(a0, a1)
+}
+
+func (c *Client) GetEx(a0 context.Context, a1 string, a2 time.Duration) *redis.StringCmd {
+	return c.client().GetEx(a0, a1, a2)
+}
+
+func (c *Client) GetRange(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StringCmd {
+	return c.client().GetRange(a0, a1, a2, a3)
+}
+
+func (c *Client) GetSet(a0 context.Context, a1 string, a2 any) *redis.StringCmd {
+	return c.client().GetSet(a0, a1, a2)
+}
+
+func (c *Client) HDel(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().HDel(a0, a1, a2...)
+}
+
+func (c *Client) HExists(a0 context.Context, a1 string, a2 string) *redis.BoolCmd {
+	return c.client().HExists(a0, a1, a2)
+}
+
+func (c *Client) HGet(a0 context.Context, a1 string, a2 string) *redis.StringCmd {
+	return c.client().HGet(a0, a1, a2)
+}
+
+func (c *Client) HGetAll(a0 context.Context, a1 string) *redis.MapStringStringCmd {
+	return c.client().HGetAll(a0, a1)
+}
+
+func (c *Client) HIncrBy(a0 context.Context, a1 string, a2 string, a3 int64) *redis.IntCmd {
+	return c.client().HIncrBy(a0, a1, a2, a3)
+}
+
+func (c *Client) HIncrByFloat(a0 context.Context, a1 string, a2 string, a3 float64) *redis.FloatCmd {
+	return c.client().HIncrByFloat(a0, a1, a2, a3)
+}
+
+func (c *Client) HKeys(a0 context.Context, a1 string) *redis.StringSliceCmd {
+	return c.client().HKeys(a0, a1)
+}
+
+func (c *Client) HLen(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().HLen(a0, a1)
+}
+
+func (c *Client) HMGet(a0 context.Context, a1 string, a2 ...string) *redis.SliceCmd {
+	return c.client().HMGet(a0, a1, a2...)
+}
+
+func (c *Client) HMSet(a0 context.Context, a1 string, a2 ...any) *redis.BoolCmd {
+	return c.client().HMSet(a0, a1, a2...)
+}
+
+func (c *Client) HRandField(a0 context.Context, a1 string, a2 int) *redis.StringSliceCmd {
+	return c.client().HRandField(a0, a1, a2)
+}
+
+func (c *Client) HRandFieldWithValues(a0 context.Context, a1 string, a2 int) *redis.KeyValueSliceCmd {
+	return c.client().HRandFieldWithValues(a0, a1, a2)
+}
+
+func (c *Client) HScan(a0 context.Context, a1 string, a2 uint64, a3 string, a4 int64) *redis.ScanCmd {
+	return c.client().HScan(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) HSet(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().HSet(a0, a1, a2...)
+}
+
+func (c *Client) HSetNX(a0 context.Context, a1 string, a2 string, a3 any) *redis.BoolCmd {
+	return c.client().HSetNX(a0, a1, a2, a3)
+}
+
+func (c *Client) HVals(a0 context.Context, a1 string) *redis.StringSliceCmd {
+	return c.client().HVals(a0, a1)
+}
+
+func (c *Client) Incr(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().Incr(a0, a1)
+}
+
+func (c *Client) IncrBy(a0 context.Context, a1 string, a2 int64) *redis.IntCmd {
+	return c.client().IncrBy(a0, a1, a2)
+}
+
+func (c *Client) IncrByFloat(a0 context.Context, a1 string, a2 float64) *redis.FloatCmd {
+	return c.client().IncrByFloat(a0, a1, a2)
+}
+
+func (c *Client) Info(a0 context.Context, a1 ...string) *redis.StringCmd {
+	return c.client().Info(a0, a1...)
+}
+
+func (c *Client) Keys(a0 context.Context, a1 string) *redis.StringSliceCmd {
+	return c.client().Keys(a0, a1)
+}
+
+func (c *Client) LCS(a0 context.Context, a1 *redis.LCSQuery) *redis.LCSCmd {
+	return c.client().LCS(a0, a1)
+}
+
+func (c *Client) LIndex(a0 context.Context, a1 string, a2 int64) *redis.StringCmd {
+	return c.client().LIndex(a0, a1, a2)
+}
+
+func (c *Client) LInsert(a0 context.Context, a1 string, a2 string, a3 any, a4 any) *redis.IntCmd {
+	return c.client().LInsert(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) LInsertAfter(a0 context.Context, a1 string, a2 any, a3 any) *redis.IntCmd {
+	return c.client().LInsertAfter(a0, a1, a2, a3)
+}
+
+func (c *Client) LInsertBefore(a0 context.Context, a1 string, a2 any, a3 any) *redis.IntCmd {
+	return c.client().LInsertBefore(a0, a1, a2, a3)
+}
+
+func (c *Client) LLen(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().LLen(a0, a1)
+}
+
+func (c *Client) LMPop(a0 context.Context, a1 string, a2 int64, a3 ...string) *redis.KeyValuesCmd {
+	return c.client().LMPop(a0, a1, a2, a3...)
+}
+
+func (c *Client) LMove(a0 context.Context, a1 string, a2 string, a3 string, a4 string) *redis.StringCmd {
+	return c.client().LMove(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) LPop(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().LPop(a0, a1)
+}
+
+func (c *Client) LPopCount(a0 context.Context, a1 string, a2 int) *redis.StringSliceCmd {
+	return c.client().LPopCount(a0, a1, a2)
+}
+
+func (c *Client) LPos(a0 context.Context, a1 string, a2 string, a3 redis.LPosArgs) *redis.IntCmd {
+	return c.client().LPos(a0, a1, a2, a3)
+}
+
+func (c *Client) LPosCount(a0 context.Context, a1 string, a2 string, a3 int64, a4 redis.LPosArgs) *redis.IntSliceCmd {
+	return c.client().LPosCount(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) LPush(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().LPush(a0, a1, a2...)
+}
+
+func (c *Client) LPushX(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().LPushX(a0, a1, a2...)
+}
+
+func (c *Client) LRange(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StringSliceCmd {
+	return c.client().LRange(a0, a1, a2, a3)
+}
+
+func (c *Client) LRem(a0 context.Context, a1 string, a2 int64, a3 any) *redis.IntCmd {
+	return c.client().LRem(a0, a1, a2, a3)
+}
+
+func (c *Client) LSet(a0 context.Context, a1 string, a2 int64, a3 any) *redis.StatusCmd {
+	return c.client().LSet(a0, a1, a2, a3)
+}
+
+func (c *Client) LTrim(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StatusCmd {
+	return c.client().LTrim(a0, a1, a2, a3)
+}
+
+func (c *Client) LastSave(a0 context.Context) *redis.IntCmd {
+	return c.client().LastSave(a0)
+}
+
+func (c *Client) MGet(a0 context.Context, a1 ...string) *redis.SliceCmd {
+	return c.client().MGet(a0, a1...)
+}
+
+func (c *Client) MSet(a0 context.Context, a1 ...any) *redis.StatusCmd {
+	return c.client().MSet(a0, a1...)
+}
+
+func (c *Client) MSetNX(a0 context.Context, a1 ...any) *redis.BoolCmd {
+	return c.client().MSetNX(a0, a1...)
+}
+
+func (c *Client) MemoryUsage(a0 context.Context, a1 string, a2 ...int) *redis.IntCmd {
+	return c.client().MemoryUsage(a0, a1, a2...)
+}
+
+func (c *Client) Migrate(a0 context.Context, a1 string, a2 string, a3 string, a4 int, a5 time.Duration) *redis.StatusCmd {
+	return c.client().Migrate(a0, a1, a2, a3, a4, a5)
+}
+
+func (c *Client) ModuleLoadex(a0 context.Context, a1 *redis.ModuleLoadexConfig) *redis.StringCmd {
+	return c.client().ModuleLoadex(a0, a1)
+}
+
+func (c *Client) Move(a0 context.Context, a1 string, a2 int) *redis.BoolCmd {
+	return c.client().Move(a0, a1, a2)
+}
+
+func (c *Client) ObjectEncoding(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().ObjectEncoding(a0, a1)
+}
+
+func (c *Client) ObjectIdleTime(a0 context.Context, a1 string) *redis.DurationCmd {
+	return c.client().ObjectIdleTime(a0, a1)
+}
+
+func (c *Client) ObjectRefCount(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().ObjectRefCount(a0, a1)
+}
+
+func (c *Client) PExpire(a0 context.Context, a1 string, a2 time.Duration) *redis.BoolCmd {
+	return c.client().PExpire(a0, a1, a2)
+}
+
+func (c *Client) PExpireAt(a0 context.Context, a1 string, a2 time.Time) *redis.BoolCmd {
+	return c.client().PExpireAt(a0, a1, a2)
+}
+
+func (c *Client) PExpireTime(a0 context.Context, a1 string) *redis.DurationCmd {
+	return c.client().PExpireTime(a0, a1)
+}
+
+func (c *Client) PFAdd(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().PFAdd(a0, a1, a2...)
+}
+
+func (c *Client) PFCount(a0 context.Context, a1 ...string) *redis.IntCmd {
+	return c.client().PFCount(a0, a1...)
+}
+
+func (c *Client) PFMerge(a0 context.Context, a1 string, a2 ...string) *redis.StatusCmd {
+	return c.client().PFMerge(a0, a1, a2...)
+}
+
+func (c *Client) PSubscribe(a0 context.Context, a1 ...string) *redis.PubSub {
+	return c.client().PSubscribe(a0, a1...)
+}
+
+func (c *Client) PTTL(a0 context.Context, a1 string) *redis.DurationCmd {
+	return c.client().PTTL(a0, a1)
+}
+
+func (c *Client) Persist(a0 context.Context, a1 string) *redis.BoolCmd {
+	return c.client().Persist(a0, a1)
+}
+
+func (c *Client) Ping(a0 context.Context) *redis.StatusCmd {
+	return c.client().Ping(a0)
+}
+
+func (c *Client) Pipeline() redis.Pipeliner {
+	return c.client().Pipeline()
+}
+
+func (c *Client) Pipelined(a0 context.Context, a1 func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+	return c.client().Pipelined(a0, a1)
+}
+
+func (c *Client) PoolStats() *redis.PoolStats {
+	return c.client().PoolStats()
+}
+
+func (c *Client) Process(a0 context.Context, a1 redis.Cmder) error {
+	return c.client().Process(a0, a1)
+}
+
+func (c *Client) PubSubChannels(a0 context.Context, a1 string) *redis.StringSliceCmd {
+	return c.client().PubSubChannels(a0, a1)
+}
+
+func (c *Client) PubSubNumPat(a0 context.Context) *redis.IntCmd {
+	return c.client().PubSubNumPat(a0)
+}
+
+func (c *Client) PubSubNumSub(a0 context.Context, a1 ...string) *redis.MapStringIntCmd {
+	return c.client().PubSubNumSub(a0, a1...)
+}
+
+func (c *Client) PubSubShardChannels(a0 context.Context, a1 string) *redis.StringSliceCmd {
+	return c.client().PubSubShardChannels(a0, a1)
+}
+
+func (c *Client) PubSubShardNumSub(a0 context.Context, a1 ...string) *redis.MapStringIntCmd {
+	return c.client().PubSubShardNumSub(a0, a1...)
+}
+
+func (c *Client) Publish(a0 context.Context, a1 string, a2 any) *redis.IntCmd {
+	return c.client().Publish(a0, a1, a2)
+}
+
+func (c *Client) Quit(a0 context.Context) *redis.StatusCmd {
+	return c.client().Quit(a0)
+}
+
+func (c *Client) RPop(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().RPop(a0, a1)
+}
+
+func (c *Client) RPopCount(a0 context.Context, a1 string, a2 int) *redis.StringSliceCmd {
+	return c.client().RPopCount(a0, a1, a2)
+}
+
+func (c *Client) RPopLPush(a0 context.Context, a1 string, a2 string) *redis.StringCmd {
+	return c.client().RPopLPush(a0, a1, a2)
+}
+
+func (c *Client) RPush(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().RPush(a0, a1, a2...)
+}
+
+func (c *Client) RPushX(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().RPushX(a0, a1, a2...)
+}
+
+func (c *Client) RandomKey(a0 context.Context) *redis.StringCmd {
+	return c.client().RandomKey(a0)
+}
+
+func (c *Client) ReadOnly(a0 context.Context) *redis.StatusCmd {
+	return c.client().ReadOnly(a0)
+}
+
+func (c *Client) ReadWrite(a0 context.Context) *redis.StatusCmd {
+	return c.client().ReadWrite(a0)
+}
+
+func (c *Client) Rename(a0 context.Context, a1 string, a2 string) *redis.StatusCmd {
+	return c.client().Rename(a0, a1, a2)
+}
+
+func (c *Client) RenameNX(a0 context.Context, a1 string, a2 string) *redis.BoolCmd {
+	return c.client().RenameNX(a0, a1, a2)
+}
+
+func (c *Client) Restore(a0 context.Context, a1 string, a2 time.Duration, a3 string) *redis.StatusCmd {
+	return c.client().Restore(a0, a1, a2, a3)
+}
+
+func (c *Client) RestoreReplace(a0 context.Context, a1 string, a2 time.Duration, a3 string) *redis.StatusCmd {
+	return c.client().RestoreReplace(a0, a1, a2, a3)
+}
+
+func (c *Client) SAdd(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().SAdd(a0, a1, a2...)
+}
+
+func (c *Client) SCard(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().SCard(a0, a1)
+}
+
+func (c *Client) SDiff(a0 context.Context, a1 ...string) *redis.StringSliceCmd {
+	return c.client().SDiff(a0, a1...)
+}
+
+func (c *Client) SDiffStore(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().SDiffStore(a0, a1, a2...)
+}
+
+func (c *Client) SInter(a0 context.Context, a1 ...string) *redis.StringSliceCmd {
+	return c.client().SInter(a0, a1...)
+}
+
+func (c *Client) SInterCard(a0 context.Context, a1 int64, a2 ...string) *redis.IntCmd {
+	return c.client().SInterCard(a0, a1, a2...)
+}
+
+func (c *Client) SInterStore(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().SInterStore(a0, a1, a2...)
+}
+
+func (c *Client) SIsMember(a0 context.Context, a1 string, a2 any) *redis.BoolCmd {
+	return c.client().SIsMember(a0, a1, a2)
+}
+
+func (c *Client) SMIsMember(a0 context.Context, a1 string, a2 ...any) *redis.BoolSliceCmd {
+	return c.client().SMIsMember(a0, a1, a2...)
+}
+
+func (c *Client) SMembers(a0 context.Context, a1 string) *redis.StringSliceCmd {
+	return c.client().SMembers(a0, a1)
+}
+
+func (c *Client) SMembersMap(a0 context.Context, a1 string) *redis.StringStructMapCmd {
+	return c.client().SMembersMap(a0, a1)
+}
+
+func (c *Client) SMove(a0 context.Context, a1 string, a2 string, a3 any) *redis.BoolCmd {
+	return c.client().SMove(a0, a1, a2, a3)
+}
+
+func (c *Client) SPop(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().SPop(a0, a1)
+}
+
+func (c *Client) SPopN(a0 context.Context, a1 string, a2 int64) *redis.StringSliceCmd {
+	return c.client().SPopN(a0, a1, a2)
+}
+
+func (c *Client) SPublish(a0 context.Context, a1 string, a2 any) *redis.IntCmd {
+	return c.client().SPublish(a0, a1, a2)
+}
+
+func (c *Client) SRandMember(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().SRandMember(a0, a1)
+}
+
+func (c *Client) SRandMemberN(a0 context.Context, a1 string, a2 int64) *redis.StringSliceCmd {
+	return c.client().SRandMemberN(a0, a1, a2)
+}
+
+func (c *Client) SRem(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().SRem(a0, a1, a2...)
+}
+
+func (c *Client) SScan(a0 context.Context, a1 string, a2 uint64, a3 string, a4 int64) *redis.ScanCmd {
+	return c.client().SScan(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) SSubscribe(a0 context.Context, a1 ...string) *redis.PubSub {
+	return c.client().SSubscribe(a0, a1...)
+}
+
+func (c *Client) SUnion(a0 context.Context, a1 ...string) *redis.StringSliceCmd {
+	return c.client().SUnion(a0, a1...)
+}
+
+func (c *Client) SUnionStore(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().SUnionStore(a0, a1, a2...)
+}
+
+func (c *Client) Save(a0 context.Context) *redis.StatusCmd {
+	return c.client().Save(a0)
+}
+
+func (c *Client) Scan(a0 context.Context, a1 uint64, a2 string, a3 int64) *redis.ScanCmd {
+	return c.client().Scan(a0, a1, a2, a3)
+}
+
+func (c *Client) ScanType(a0 context.Context, a1 uint64, a2 string, a3 int64, a4 string) *redis.ScanCmd {
+	return c.client().ScanType(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) ScriptExists(a0 context.Context, a1 ...string) *redis.BoolSliceCmd {
+	return c.client().ScriptExists(a0, a1...)
+}
+
+func (c *Client) ScriptFlush(a0 context.Context) *redis.StatusCmd {
+	return c.client().ScriptFlush(a0)
+}
+
+func (c *Client) ScriptKill(a0 context.Context) *redis.StatusCmd {
+	return c.client().ScriptKill(a0)
+}
+
+func (c *Client) ScriptLoad(a0 context.Context, a1 string) *redis.StringCmd {
+	return c.client().ScriptLoad(a0, a1)
+}
+
+func (c *Client) Set(a0 context.Context, a1 string, a2 any, a3 time.Duration) *redis.StatusCmd {
+	return c.client().Set(a0, a1, a2, a3)
+}
+
+func (c *Client) SetArgs(a0 context.Context, a1 string, a2 any, a3 redis.SetArgs) *redis.StatusCmd {
+	return c.client().SetArgs(a0, a1, a2, a3)
+}
+
+func (c *Client) SetBit(a0 context.Context, a1 string, a2 int64, a3 int) *redis.IntCmd {
+	return c.client().SetBit(a0, a1, a2, a3)
+}
+
+func (c *Client) SetEx(a0 context.Context, a1 string, a2 any, a3 time.Duration) *redis.StatusCmd {
+	return c.client().SetEx(a0, a1, a2, a3)
+}
+
+func (c *Client) SetNX(a0 context.Context, a1 string, a2 any, a3 time.Duration) *redis.BoolCmd {
+	return c.client().SetNX(a0, a1, a2, a3)
+}
+
+func (c *Client) SetRange(a0 context.Context, a1 string, a2 int64, a3 string) *redis.IntCmd {
+	return c.client().SetRange(a0, a1, a2, a3)
+}
+
+func (c *Client) SetXX(a0 context.Context, a1 string, a2 any, a3 time.Duration) *redis.BoolCmd {
+	return c.client().SetXX(a0, a1, a2, a3)
+}
+
+func (c *Client) Shutdown(a0 context.Context) *redis.StatusCmd {
+	return c.client().Shutdown(a0)
+}
+
+func (c *Client) ShutdownNoSave(a0 context.Context) *redis.StatusCmd {
+	return c.client().ShutdownNoSave(a0)
+}
+
+func (c *Client) ShutdownSave(a0 context.Context) *redis.StatusCmd {
+	return c.client().ShutdownSave(a0)
+}
+
+func (c *Client) SlaveOf(a0 context.Context, a1 string, a2 string) *redis.StatusCmd {
+	return c.client().SlaveOf(a0, a1, a2)
+}
+
+func (c *Client) SlowLogGet(a0 context.Context, a1 int64) *redis.SlowLogCmd {
+	return c.client().SlowLogGet(a0, a1)
+}
+
+func (c *Client) Sort(a0 context.Context, a1 string, a2 *redis.Sort) *redis.StringSliceCmd {
+	return c.client().Sort(a0, a1, a2)
+}
+
+func (c *Client) SortInterfaces(a0 context.Context, a1 string, a2 *redis.Sort) *redis.SliceCmd {
+	return c.client().SortInterfaces(a0, a1, a2)
+}
+
+func (c *Client) SortRO(a0 context.Context, a1 string, a2 *redis.Sort) *redis.StringSliceCmd {
+	return c.client().SortRO(a0, a1, a2)
+}
+
+func (c *Client) SortStore(a0 context.Context, a1 string, a2 string, a3 *redis.Sort) *redis.IntCmd {
+	return c.client().SortStore(a0, a1, a2, a3)
+}
+
+func (c *Client) StrLen(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().StrLen(a0, a1)
+}
+
+func (c *Client) Subscribe(a0 context.Context, a1 ...string) *redis.PubSub {
+	return c.client().Subscribe(a0, a1...)
+}
+
+func (c *Client) TDigestAdd(a0 context.Context, a1 string, a2 ...float64) *redis.StatusCmd {
+	return c.client().TDigestAdd(a0, a1, a2...)
+}
+
+func (c *Client) TDigestByRank(a0 context.Context, a1 string, a2 ...uint64) *redis.FloatSliceCmd {
+	return c.client().TDigestByRank(a0, a1, a2...)
+}
+
+func (c *Client) TDigestByRevRank(a0 context.Context, a1 string, a2 ...uint64) *redis.FloatSliceCmd {
+	return c.client().TDigestByRevRank(a0, a1, a2...)
+}
+
+func (c *Client) TDigestCDF(a0 context.Context, a1 string, a2 ...float64) *redis.FloatSliceCmd {
+	return c.client().TDigestCDF(a0, a1, a2...)
+}
+
+func (c *Client) TDigestCreate(a0 context.Context, a1 string) *redis.StatusCmd {
+	return c.client().TDigestCreate(a0, a1)
+}
+
+func (c *Client) TDigestCreateWithCompression(a0 context.Context, a1 string, a2 int64) *redis.StatusCmd {
+	return c.client().TDigestCreateWithCompression(a0, a1, a2)
+}
+
+func (c *Client) TDigestInfo(a0 context.Context, a1 string) *redis.TDigestInfoCmd {
+	return c.client().TDigestInfo(a0, a1)
+}
+
+func (c *Client) TDigestMax(a0 context.Context, a1 string) *redis.FloatCmd {
+	return c.client().TDigestMax(a0, a1)
+}
+
+func (c *Client) TDigestMerge(a0 context.Context, a1 string, a2 *redis.TDigestMergeOptions, a3 ...string) *redis.StatusCmd {
+	return c.client().TDigestMerge(a0, a1, a2, a3...)
+}
+
+func (c *Client) TDigestMin(a0 context.Context, a1 string) *redis.FloatCmd {
+	return c.client().TDigestMin(a0, a1)
+}
+
+func (c *Client) TDigestQuantile(a0 context.Context, a1 string, a2 ...float64) *redis.FloatSliceCmd {
+	return c.client().TDigestQuantile(a0, a1, a2...)
+}
+
+func (c *Client) TDigestRank(a0 context.Context, a1 string, a2 ...float64) *redis.IntSliceCmd {
+	return c.client().TDigestRank(a0, a1, a2...)
+}
+
+func (c *Client) TDigestReset(a0 context.Context, a1 string) *redis.StatusCmd {
+	return c.client().TDigestReset(a0, a1)
+}
+
+func (c *Client) TDigestRevRank(a0 context.Context, a1 string, a2 ...float64) *redis.IntSliceCmd {
+	return c.client().TDigestRevRank(a0, a1, a2...)
+}
+
+func (c *Client) TDigestTrimmedMean(a0 context.Context, a1 string, a2 float64, a3 float64) *redis.FloatCmd {
+	return c.client().TDigestTrimmedMean(a0, a1, a2, a3)
+}
+
+func (c *Client) TFCall(a0 context.Context, a1 string, a2 string, a3 int) *redis.Cmd {
+	return c.client().TFCall(a0, a1, a2, a3)
+}
+
+func (c *Client) TFCallASYNC(a0 context.Context, a1 string, a2 string, a3 int) *redis.Cmd {
+	return c.client().TFCallASYNC(a0, a1, a2, a3)
+}
+
+func (c *Client) TFCallASYNCArgs(a0 context.Context, a1 string, a2 string, a3 int, a4 *redis.TFCallOptions) *redis.Cmd {
+	return c.client().TFCallASYNCArgs(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) TFCallArgs(a0 context.Context, a1 string, a2 string, a3 int, a4 *redis.TFCallOptions) *redis.Cmd {
+	return c.client().TFCallArgs(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) TFunctionDelete(a0 context.Context, a1 string) *redis.StatusCmd {
+	return c.client().TFunctionDelete(a0, a1)
+}
+
+func (c *Client) TFunctionList(a0 context.Context) *redis.MapStringInterfaceSliceCmd {
+	return c.client().TFunctionList(a0)
+}
+
+func (c *Client) TFunctionListArgs(a0 context.Context, a1 *redis.TFunctionListOptions) *redis.MapStringInterfaceSliceCmd {
+	return c.client().TFunctionListArgs(a0, a1)
+}
+
+func (c *Client) TFunctionLoad(a0 context.Context, a1 string) *redis.StatusCmd {
+	return c.client().TFunctionLoad(a0, a1)
+}
+
+func (c *Client) TFunctionLoadArgs(a0 context.Context, a1 string, a2 *redis.TFunctionLoadOptions) *redis.StatusCmd {
+	return c.client().TFunctionLoadArgs(a0, a1, a2)
+}
+
+func (c *Client) TTL(a0 context.Context, a1 string) *redis.DurationCmd {
+	return c.client().TTL(a0, a1)
+}
+
+func (c *Client) Time(a0 context.Context) *redis.TimeCmd {
+	return c.client().Time(a0)
+}
+
+func (c *Client) TopKAdd(a0 context.Context, a1 string, a2 ...any) *redis.StringSliceCmd {
+	return c.client().TopKAdd(a0, a1, a2...)
+}
+
+func (c *Client) TopKCount(a0 context.Context, a1 string, a2 ...any) *redis.IntSliceCmd {
+	return c.client().TopKCount(a0, a1, a2...)
+}
+
+func (c *Client) TopKIncrBy(a0 context.Context, a1 string, a2 ...any) *redis.StringSliceCmd {
+	return c.client().TopKIncrBy(a0, a1, a2...)
+}
+
+func (c *Client) TopKInfo(a0 context.Context, a1 string) *redis.TopKInfoCmd {
+	return c.client().TopKInfo(a0, a1)
+}
+
+func (c *Client) TopKList(a0 context.Context, a1 string) *redis.StringSliceCmd {
+	return c.client().TopKList(a0, a1)
+}
+
+func (c *Client) TopKListWithCount(a0 context.Context, a1 string) *redis.MapStringIntCmd {
+	return c.client().TopKListWithCount(a0, a1)
+}
+
+func (c *Client) TopKQuery(a0 context.Context, a1 string, a2 ...any) *redis.BoolSliceCmd {
+	return c.client().TopKQuery(a0, a1, a2...)
+}
+
+func (c *Client) TopKReserve(a0 context.Context, a1 string, a2 int64) *redis.StatusCmd {
+	return c.client().TopKReserve(a0, a1, a2)
+}
+
+func (c *Client) TopKReserveWithOptions(a0 context.Context, a1 string, a2 int64, a3 int64, a4 int64, a5 float64) *redis.StatusCmd {
+	return c.client().TopKReserveWithOptions(a0, a1, a2, a3, a4, a5)
+}
+
+func (c *Client) Touch(a0 context.Context, a1 ...string) *redis.IntCmd {
+	return c.client().Touch(a0, a1...)
+}
+
+func (c *Client) TxPipeline() redis.Pipeliner {
+	return c.client().TxPipeline()
+}
+
+func (c *Client) TxPipelined(a0 context.Context, a1 func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+	return c.client().TxPipelined(a0, a1)
+}
+
+func (c *Client) Type(a0 context.Context, a1 string) *redis.StatusCmd {
+	return c.client().Type(a0, a1)
+}
+
+func (c *Client) Unlink(a0 context.Context, a1 ...string) *redis.IntCmd {
+	return c.client().Unlink(a0, a1...)
+}
+
+func (c *Client) Watch(a0 context.Context, a1 func(*redis.Tx) error, a2 ...string) error {
+	return c.client().Watch(a0, a1, a2...)
+}
+
+func (c *Client) XAck(a0 context.Context, a1 string, a2 string, a3 ...string) *redis.IntCmd {
+	return c.client().XAck(a0, a1, a2, a3...)
+}
+
+func (c *Client) XAdd(a0 context.Context, a1 *redis.XAddArgs) *redis.StringCmd {
+	return c.client().XAdd(a0, a1)
+}
+
+func (c *Client) XAutoClaim(a0 context.Context, a1 *redis.XAutoClaimArgs) *redis.XAutoClaimCmd {
+	return c.client().XAutoClaim(a0, a1)
+}
+
+func (c *Client) XAutoClaimJustID(a0 context.Context, a1 *redis.XAutoClaimArgs) *redis.XAutoClaimJustIDCmd {
+	return c.client().XAutoClaimJustID(a0, a1)
+}
+
+func (c *Client) XClaim(a0 context.Context, a1 *redis.XClaimArgs) *redis.XMessageSliceCmd {
+	return c.client().XClaim(a0, a1)
+}
+
+func (c *Client) XClaimJustID(a0 context.Context, a1 *redis.XClaimArgs) *redis.StringSliceCmd {
+	return c.client().XClaimJustID(a0, a1)
+}
+
+func (c *Client) XDel(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().XDel(a0, a1, a2...)
+}
+
+func (c *Client) XGroupCreate(a0 context.Context, a1 string, a2 string, a3 string) *redis.StatusCmd {
+	return c.client().XGroupCreate(a0, a1, a2, a3)
+}
+
+func (c *Client) XGroupCreateConsumer(a0 context.Context, a1 string, a2 string, a3 string) *redis.IntCmd {
+	return c.client().XGroupCreateConsumer(a0, a1, a2, a3)
+}
+
+func (c *Client) XGroupCreateMkStream(a0 context.Context, a1 string, a2 string, a3 string) *redis.StatusCmd {
+	return c.client().XGroupCreateMkStream(a0, a1, a2, a3)
+}
+
+func (c *Client) XGroupDelConsumer(a0 context.Context, a1 string, a2 string, a3 string) *redis.IntCmd {
+	return c.client().XGroupDelConsumer(a0, a1, a2, a3)
+}
+
+func (c *Client) XGroupDestroy(a0 context.Context, a1 string, a2 string) *redis.IntCmd {
+	return c.client().XGroupDestroy(a0, a1, a2)
+}
+
+func (c *Client) XGroupSetID(a0 context.Context, a1 string, a2 string, a3 string) *redis.StatusCmd {
+	return c.client().XGroupSetID(a0, a1, a2, a3)
+}
+
+func (c *Client) XInfoConsumers(a0 context.Context, a1 string, a2 string) *redis.XInfoConsumersCmd {
+	return c.client().XInfoConsumers(a0, a1, a2)
+}
+
+func (c *Client) XInfoGroups(a0 context.Context, a1 string) *redis.XInfoGroupsCmd {
+	return c.client().XInfoGroups(a0, a1)
+}
+
+func (c *Client) XInfoStream(a0 context.Context, a1 string) *redis.XInfoStreamCmd {
+	return c.client().XInfoStream(a0, a1)
+}
+
+func (c *Client) XInfoStreamFull(a0 context.Context, a1 string, a2 int) *redis.XInfoStreamFullCmd {
+	return c.client().XInfoStreamFull(a0, a1, a2)
+}
+
+func (c *Client) XLen(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().XLen(a0, a1)
+}
+
+func (c *Client) XPending(a0 context.Context, a1 string, a2 string) *redis.XPendingCmd {
+	return c.client().XPending(a0, a1, a2)
+}
+
+func (c *Client) XPendingExt(a0 context.Context, a1 *redis.XPendingExtArgs) *redis.XPendingExtCmd {
+	return c.client().XPendingExt(a0, a1)
+}
+
+func (c *Client) XRange(a0 context.Context, a1 string, a2 string, a3 string) *redis.XMessageSliceCmd {
+	return c.client().XRange(a0, a1, a2, a3)
+}
+
+func (c *Client) XRangeN(a0 context.Context, a1 string, a2 string, a3 string, a4 int64) *redis.XMessageSliceCmd {
+	return c.client().XRangeN(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) XRead(a0 context.Context, a1 *redis.XReadArgs) *redis.XStreamSliceCmd {
+	return c.client().XRead(a0, a1)
+}
+
+func (c *Client) XReadGroup(a0 context.Context, a1 *redis.XReadGroupArgs) *redis.XStreamSliceCmd {
+	return c.client().XReadGroup(a0, a1)
+}
+
+func (c *Client) XReadStreams(a0 context.Context, a1 ...string) *redis.XStreamSliceCmd {
+	return c.client().XReadStreams(a0, a1...)
+}
+
+func (c *Client) XRevRange(a0 context.Context, a1 string, a2 string, a3 string) *redis.XMessageSliceCmd {
+	return c.client().XRevRange(a0, a1, a2, a3)
+}
+
+func (c *Client) XRevRangeN(a0 context.Context, a1 string, a2 string, a3 string, a4 int64) *redis.XMessageSliceCmd {
+	return c.client().XRevRangeN(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) XTrimMaxLen(a0 context.Context, a1 string, a2 int64) *redis.IntCmd {
+	return c.client().XTrimMaxLen(a0, a1, a2)
+}
+
+func (c *Client) XTrimMaxLenApprox(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.IntCmd {
+	return c.client().XTrimMaxLenApprox(a0, a1, a2, a3)
+}
+
+func (c *Client) XTrimMinID(a0 context.Context, a1 string, a2 string) *redis.IntCmd {
+	return c.client().XTrimMinID(a0, a1, a2)
+}
+
+func (c *Client) XTrimMinIDApprox(a0 context.Context, a1 string, a2 string, a3 int64) *redis.IntCmd {
+	return c.client().XTrimMinIDApprox(a0, a1, a2, a3)
+}
+
+func (c *Client) ZAdd(a0 context.Context, a1 string, a2 ...redis.Z) *redis.IntCmd {
+	return c.client().ZAdd(a0, a1, a2...)
+}
+
+func (c *Client) ZAddArgs(a0 context.Context, a1 string, a2 redis.ZAddArgs) *redis.IntCmd {
+	return c.client().ZAddArgs(a0, a1, a2)
+}
+
+func (c *Client) ZAddArgsIncr(a0 context.Context, a1 string, a2 redis.ZAddArgs) *redis.FloatCmd {
+	return c.client().ZAddArgsIncr(a0, a1, a2)
+}
+
+func (c *Client) ZAddGT(a0 context.Context, a1 string, a2 ...redis.Z) *redis.IntCmd {
+	return c.client().ZAddGT(a0, a1, a2...)
+}
+
+func (c *Client) ZAddLT(a0 context.Context, a1 string, a2 ...redis.Z) *redis.IntCmd {
+	return c.client().ZAddLT(a0, a1, a2...)
+}
+
+func (c *Client) ZAddNX(a0 context.Context, a1 string, a2 ...redis.Z) *redis.IntCmd {
+	return c.client().ZAddNX(a0, a1, a2...)
+}
+
+func (c *Client) ZAddXX(a0 context.Context, a1 string, a2 ...redis.Z) *redis.IntCmd {
+	return c.client().ZAddXX(a0, a1, a2...)
+}
+
+func (c *Client) ZCard(a0 context.Context, a1 string) *redis.IntCmd {
+	return c.client().ZCard(a0, a1)
+}
+
+func (c *Client) ZCount(a0 context.Context, a1 string, a2 string, a3 string) *redis.IntCmd {
+	return c.client().ZCount(a0, a1, a2, a3)
+}
+
+func (c *Client) ZDiff(a0 context.Context, a1 ...string) *redis.StringSliceCmd {
+	return c.client().ZDiff(a0, a1...)
+}
+
+func (c *Client) ZDiffStore(a0 context.Context, a1 string, a2 ...string) *redis.IntCmd {
+	return c.client().ZDiffStore(a0, a1, a2...)
+}
+
+func (c *Client) ZDiffWithScores(a0 context.Context, a1 ...string) *redis.ZSliceCmd {
+	return c.client().ZDiffWithScores(a0, a1...)
+}
+
+func (c *Client) ZIncrBy(a0 context.Context, a1 string, a2 float64, a3 string) *redis.FloatCmd {
+	return c.client().ZIncrBy(a0, a1, a2, a3)
+}
+
+func (c *Client) ZInter(a0 context.Context, a1 *redis.ZStore) *redis.StringSliceCmd {
+	return c.client().ZInter(a0, a1)
+}
+
+func (c *Client) ZInterCard(a0 context.Context, a1 int64, a2 ...string) *redis.IntCmd {
+	return c.client().ZInterCard(a0, a1, a2...)
+}
+
+func (c *Client) ZInterStore(a0 context.Context, a1 string, a2 *redis.ZStore) *redis.IntCmd {
+	return c.client().ZInterStore(a0, a1, a2)
+}
+
+func (c *Client) ZInterWithScores(a0 context.Context, a1 *redis.ZStore) *redis.ZSliceCmd {
+	return c.client().ZInterWithScores(a0, a1)
+}
+
+func (c *Client) ZLexCount(a0 context.Context, a1 string, a2 string, a3 string) *redis.IntCmd {
+	return c.client().ZLexCount(a0, a1, a2, a3)
+}
+
+func (c *Client) ZMPop(a0 context.Context, a1 string, a2 int64, a3 ...string) *redis.ZSliceWithKeyCmd {
+	return c.client().ZMPop(a0, a1, a2, a3...)
+}
+
+func (c *Client) ZMScore(a0 context.Context, a1 string, a2 ...string) *redis.FloatSliceCmd {
+	return c.client().ZMScore(a0, a1, a2...)
+}
+
+func (c *Client) ZPopMax(a0 context.Context, a1 string, a2 ...int64) *redis.ZSliceCmd {
+	return c.client().ZPopMax(a0, a1, a2...)
+}
+
+func (c *Client) ZPopMin(a0 context.Context, a1 string, a2 ...int64) *redis.ZSliceCmd {
+	return c.client().ZPopMin(a0, a1, a2...)
+}
+
+func (c *Client) ZRandMember(a0 context.Context, a1 string, a2 int) *redis.StringSliceCmd {
+	return c.client().ZRandMember(a0, a1, a2)
+}
+
+func (c *Client) ZRandMemberWithScores(a0 context.Context, a1 string, a2 int) *redis.ZSliceCmd {
+	return c.client().ZRandMemberWithScores(a0, a1, a2)
+}
+
+func (c *Client) ZRange(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StringSliceCmd {
+	return c.client().ZRange(a0, a1, a2, a3)
+}
+
+func (c *Client) ZRangeArgs(a0 context.Context, a1 redis.ZRangeArgs) *redis.StringSliceCmd {
+	return c.client().ZRangeArgs(a0, a1)
+}
+
+func (c *Client) ZRangeArgsWithScores(a0 context.Context, a1 redis.ZRangeArgs) *redis.ZSliceCmd {
+	return c.client().ZRangeArgsWithScores(a0, a1)
+}
+
+func (c *Client) ZRangeByLex(a0 context.Context, a1 string, a2 *redis.ZRangeBy) *redis.StringSliceCmd {
+	return c.client().ZRangeByLex(a0, a1, a2)
+}
+
+func (c *Client) ZRangeByScore(a0 context.Context, a1 string, a2 *redis.ZRangeBy) *redis.StringSliceCmd {
+	return c.client().ZRangeByScore(a0, a1, a2)
+}
+
+func (c *Client) ZRangeByScoreWithScores(a0 context.Context, a1 string, a2 *redis.ZRangeBy) *redis.ZSliceCmd {
+	return c.client().ZRangeByScoreWithScores(a0, a1, a2)
+}
+
+func (c *Client) ZRangeStore(a0 context.Context, a1 string, a2 redis.ZRangeArgs) *redis.IntCmd {
+	return c.client().ZRangeStore(a0, a1, a2)
+}
+
+func (c *Client) ZRangeWithScores(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.ZSliceCmd {
+	return c.client().ZRangeWithScores(a0, a1, a2, a3)
+}
+
+func (c *Client) ZRank(a0 context.Context, a1 string, a2 string) *redis.IntCmd {
+	return c.client().ZRank(a0, a1, a2)
+}
+
+func (c *Client) ZRankWithScore(a0 context.Context, a1 string, a2 string) *redis.RankWithScoreCmd {
+	return c.client().ZRankWithScore(a0, a1, a2)
+}
+
+func (c *Client) ZRem(a0 context.Context, a1 string, a2 ...any) *redis.IntCmd {
+	return c.client().ZRem(a0, a1, a2...)
+}
+
+func (c *Client) ZRemRangeByLex(a0 context.Context, a1 string, a2 string, a3 string) *redis.IntCmd {
+	return c.client().ZRemRangeByLex(a0, a1, a2, a3)
+}
+
+func (c *Client) ZRemRangeByRank(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.IntCmd {
+	return c.client().ZRemRangeByRank(a0, a1, a2, a3)
+}
+
+func (c *Client) ZRemRangeByScore(a0 context.Context, a1 string, a2 string, a3 string) *redis.IntCmd {
+	return c.client().ZRemRangeByScore(a0, a1, a2, a3)
+}
+
+func (c *Client) ZRevRange(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.StringSliceCmd {
+	return c.client().ZRevRange(a0, a1, a2, a3)
+}
+
+func (c *Client) ZRevRangeByLex(a0 context.Context, a1 string, a2 *redis.ZRangeBy) *redis.StringSliceCmd {
+	return c.client().ZRevRangeByLex(a0, a1, a2)
+}
+
+func (c *Client) ZRevRangeByScore(a0 context.Context, a1 string, a2 *redis.ZRangeBy) *redis.StringSliceCmd {
+	return c.client().ZRevRangeByScore(a0, a1, a2)
+}
+
+func (c *Client) ZRevRangeByScoreWithScores(a0 context.Context, a1 string, a2 *redis.ZRangeBy) *redis.ZSliceCmd {
+	return c.client().ZRevRangeByScoreWithScores(a0, a1, a2)
+}
+
+func (c *Client) ZRevRangeWithScores(a0 context.Context, a1 string, a2 int64, a3 int64) *redis.ZSliceCmd {
+	return c.client().ZRevRangeWithScores(a0, a1, a2, a3)
+}
+
+func (c *Client) ZRevRank(a0 context.Context, a1 string, a2 string) *redis.IntCmd {
+	return c.client().ZRevRank(a0, a1, a2)
+}
+
+func (c *Client) ZRevRankWithScore(a0 context.Context, a1 string, a2 string) *redis.RankWithScoreCmd {
+	return c.client().ZRevRankWithScore(a0, a1, a2)
+}
+
+func (c *Client) ZScan(a0 context.Context, a1 string, a2 uint64, a3 string, a4 int64) *redis.ScanCmd {
+	return c.client().ZScan(a0, a1, a2, a3, a4)
+}
+
+func (c *Client) ZScore(a0 context.Context, a1 string, a2 string) *redis.FloatCmd {
+	return c.client().ZScore(a0, a1, a2)
+}
+
+func (c *Client) ZUnion(a0 context.Context, a1 redis.ZStore) *redis.StringSliceCmd {
+	return c.client().ZUnion(a0, a1)
+}
+
+func (c *Client) ZUnionStore(a0 context.Context, a1 string, a2 *redis.ZStore) *redis.IntCmd {
+	return c.client().ZUnionStore(a0, a1, a2)
+}
+
+func (c *Client) ZUnionWithScores(a0 context.Context, a1 redis.ZStore) *redis.ZSliceCmd {
+	return c.client().ZUnionWithScores(a0, a1)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"reflect"
 	"sync"
 	"time"
 
@@ -12,10 +13,26 @@ import (
 	"github.com/yrbb/rain/pkg/logger"
 )
 
+const (
+	ModeStandalone = "standalone"
+	ModeCluster    = "cluster"
+	ModeFailover   = "failover"
+)
+
 type Config struct {
 	Disable         bool          `toml:"disable"`
 	Name            string        `toml:"name"`
-	Addr            string        `toml:"addr"`           // redis://<user>:<pass>@localhost:6379/<db>
+	Mode            string        `toml:"mode"`           // standalone, cluster, failover, 默认 standalone
+	Addr            string        `toml:"addr"`           // standalone: redis://<user>:<pass>@localhost:6379/<db>
+	Addrs           []string      `toml:"addrs"`          // cluster 节点地址 / failover sentinel 地址
+	MasterName      string        `toml:"master_name"`    // failover
+	Username        string        `toml:"username"`       // cluster, failover
+	Password        string        `toml:"password"`       // cluster, failover
+	DB              int           `toml:"db"`             // failover
+	SentinelPass    string        `toml:"sentinel_pass"`  // failover
+	ReadOnly        bool          `toml:"read_only"`      // cluster, failover 允许从节点读
+	RouteByLatency  bool          `toml:"route_latency"`  // cluster, failover 只读命令路由到延迟最低的节点
+	RouteRandomly   bool          `toml:"route_randomly"` // cluster, failover 只读命令随机路由
 	DialTimeout     time.Duration `toml:"dial_timeout"`   // default 5s
 	ReadTimeout     time.Duration `toml:"read_timeout"`   // default 3s
 	WriteTimeout    time.Duration `toml:"write_timeout"`  // default 3s
@@ -25,11 +42,39 @@ type Config struct {
 	MaxIdleConns    int           `toml:"max_idle_conns"` // default 0
 	ConnMaxIdleTime time.Duration `toml:"max_open_conns"` // default 30分钟
 	ConnMaxLifeTime time.Duration `toml:"delay_connect"`  // default 0
+	SlowThreshold   int           `toml:"slow_threshold"` // 慢命令阈值(毫秒), 超过后以 warn 级别记录, 0 表示不记录
+}
+
+func (c *Config) validate() error {
+	switch c.Mode {
+	case "":
+		c.Mode = ModeStandalone
+	case ModeStandalone:
+	case ModeCluster, ModeFailover:
+		if len(c.Addrs) == 0 {
+			return fmt.Errorf("Redis %s 未配置 addrs", c.Name)
+		}
+
+		if c.Mode == ModeFailover && c.MasterName == "" {
+			return fmt.Errorf("Redis %s 未配置 master_name", c.Name)
+		}
+	default:
+		return fmt.Errorf("Redis %s 不支持的模式: %s", c.Name, c.Mode)
+	}
+
+	return nil
 }
 
+// drainTimeout 热更新后等待旧客户端执行中命令完成的最长时间
+const drainTimeout = 10 * time.Second
+
 type Redis struct {
+	mu      sync.Mutex
+	debug   bool
+	configs map[string]Config // name => 当前生效的配置
+
 	hooks sync.Map // name => *LogHook
-	list  sync.Map // name => *redis.Client
+	list  sync.Map // name => *Client
 }
 
 func New(configs []Config) (*Redis, error) {
@@ -47,7 +92,7 @@ func New(configs []Config) (*Redis, error) {
 		return nil, nil
 	}
 
-	m := &Redis{}
+	m := &Redis{configs: map[string]Config{}}
 
 	for _, v := range configs {
 		v := v
@@ -69,27 +114,119 @@ func New(configs []Config) (*Redis, error) {
 }
 
 func (m *Redis) create(c *Config) error {
-	opts, err := redis.ParseURL(c.Addr)
+	rdb, err := m.connect(c)
 	if err != nil {
 		return err
 	}
 
-	m.setOptions(opts, c)
+	m.list.Store(c.Name, wrapClient(rdb))
+	m.configs[c.Name] = *c
 
-	rdb := redis.NewClient(opts)
+	return nil
+}
+
+// connect 校验配置, 创建客户端并确认连接可用
+func (m *Redis) connect(c *Config) (redis.UniversalClient, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	rdb, err := m.newClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	cmd := rdb.Ping(context.Background())
 	if cmd.Err() != nil {
-		return cmd.Err()
+		_ = rdb.Close()
+		return nil, cmd.Err()
 	}
 
-	logHook := &LogHook{Name: c.Name}
+	logHook := &LogHook{
+		Name:          c.Name,
+		Debug:         m.debug,
+		SlowThreshold: time.Duration(c.SlowThreshold) * time.Millisecond,
+	}
 	rdb.AddHook(logHook)
 
 	m.hooks.Store(c.Name, logHook)
-	m.list.Store(c.Name, rdb)
 
-	return nil
+	return rdb, nil
+}
+
+func (m *Redis) newClient(c *Config) (redis.UniversalClient, error) {
+	switch c.Mode {
+	case ModeCluster:
+		return redis.NewClusterClient(m.universalOptions(c).Cluster()), nil
+	case ModeFailover:
+		opts := m.universalOptions(c).Failover()
+		if !c.ReadOnly && !c.RouteByLatency && !c.RouteRandomly {
+			return redis.NewFailoverClient(opts), nil
+		}
+
+		opts.RouteByLatency = c.RouteByLatency
+		opts.RouteRandomly = c.RouteRandomly
+
+		return redis.NewFailoverClusterClient(opts), nil
+	default:
+		opts, err := redis.ParseURL(c.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		m.setOptions(opts, c)
+
+		return redis.NewClient(opts), nil
+	}
+}
+
+func (m *Redis) universalOptions(c *Config) *redis.UniversalOptions {
+	opts := &redis.UniversalOptions{
+		Addrs:            c.Addrs,
+		MasterName:       c.MasterName,
+		Username:         c.Username,
+		Password:         c.Password,
+		DB:               c.DB,
+		SentinelPassword: c.SentinelPass,
+		ReadOnly:         c.ReadOnly,
+		RouteByLatency:   c.RouteByLatency,
+		RouteRandomly:    c.RouteRandomly,
+		PoolFIFO:         c.PoolFIFO,
+	}
+
+	if c.DialTimeout > 0 {
+		opts.DialTimeout = time.Second * c.DialTimeout
+	}
+
+	if c.ReadTimeout > 0 {
+		opts.ReadTimeout = time.Second * c.ReadTimeout
+	}
+
+	if c.WriteTimeout > 0 {
+		opts.WriteTimeout = time.Second * c.WriteTimeout
+	}
+
+	if c.PoolSize > 0 {
+		opts.PoolSize = c.PoolSize
+	}
+
+	if c.MinIdleConns > 0 {
+		opts.MinIdleConns = c.MinIdleConns
+	}
+
+	if c.MaxIdleConns > 0 {
+		opts.MaxIdleConns = c.MaxIdleConns
+	}
+
+	if c.ConnMaxIdleTime > 0 {
+		opts.ConnMaxIdleTime = time.Second * c.ConnMaxIdleTime
+	}
+
+	if c.ConnMaxLifeTime > 0 {
+		opts.ConnMaxLifetime = time.Second * c.ConnMaxLifeTime
+	}
+
+	return opts
 }
 
 func (m *Redis) setOptions(opts *redis.Options, c *Config) {
@@ -128,47 +265,107 @@ func (m *Redis) setOptions(opts *redis.Options, c *Config) {
 	}
 }
 
-func (m *Redis) Get(name ...string) (*redis.Client, error) {
+// Get 返回稳定的客户端句柄, 配置热更新后句柄继续可用
+func (m *Redis) Get(name ...string) (redis.UniversalClient, error) {
 	if len(name) == 0 {
 		name = []string{"default"}
 	}
 
 	if tmp, ok := m.list.Load(name[0]); ok {
-		return tmp.(*redis.Client), nil
+		return tmp.(*Client), nil
 	}
 
 	return nil, fmt.Errorf("Redis 资源不存在: %s", name[0])
 }
 
+// UpdateConfig 对比新旧配置: 仅重建配置变化的客户端, 新增或移除实例, 旧客户端在执行中的命令完成后关闭.
+// 新配置无法连接时保留旧客户端, 存在失败时返回 false
 func (m *Redis) UpdateConfig(c []Config) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	next := map[string]bool{}
+
 	for _, v := range c {
 		v := v
 
-		tmp, ok := m.list.Load(v.Name)
-		if !ok {
+		if v.Disable {
 			continue
 		}
 
-		rc := tmp.(*redis.Client)
+		next[v.Name] = true
 
-		if err := m.create(&v); err != nil {
+		if err := v.validate(); err != nil {
+			logger.M().Error("Redis 配置异常", slog.String("name", v.Name), slog.String("error", err.Error()))
+			ok = false
+
+			continue
+		}
+
+		if old, exists := m.configs[v.Name]; exists && reflect.DeepEqual(old, v) {
+			continue
+		}
+
+		rdb, err := m.connect(&v)
+		if err != nil {
 			logger.M().Error("初始化 Redis 资源异常", slog.String("name", v.Name), slog.String("error", err.Error()))
-			m.list.Store(v.Name, rc)
+			ok = false
 
 			continue
 		}
 
-		logger.M().Info(fmt.Sprintf("初始化 Redis %s", v.Name))
+		if tmp, exists := m.list.Load(v.Name); exists {
+			go m.drain(v.Name, tmp.(*Client).swap(rdb))
+			logger.M().Info(fmt.Sprintf("更新 Redis %s", v.Name))
+		} else {
+			m.list.Store(v.Name, wrapClient(rdb))
+			logger.M().Info(fmt.Sprintf("添加 Redis %s", v.Name))
+		}
+
+		m.configs[v.Name] = v
+	}
+
+	for name := range m.configs {
+		if next[name] {
+			continue
+		}
 
-		if err := rc.Close(); err != nil {
-			logger.M().Error("关闭 Redis 资源异常", slog.String("name", v.Name), slog.String("error", err.Error()))
+		if tmp, exists := m.list.LoadAndDelete(name); exists {
+			go m.drain(name, tmp.(*Client).client())
 		}
+
+		m.hooks.Delete(name)
+		delete(m.configs, name)
+
+		logger.M().Info(fmt.Sprintf("移除 Redis %s", name))
 	}
 
-	return true
+	return ok
+}
+
+// drain 等待连接池中的连接全部归还 (执行中的命令完成) 后关闭客户端
+func (m *Redis) drain(name string, rdb redis.UniversalClient) {
+	deadline := time.Now().Add(drainTimeout)
+
+	for time.Now().Before(deadline) {
+		if s := rdb.PoolStats(); s.TotalConns <= s.IdleConns {
+			break
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err := rdb.Close(); err != nil {
+		logger.M().Error("关闭 Redis 资源异常", slog.String("name", name), slog.String("error", err.Error()))
+	}
 }
 
 func (m *Redis) SetDebug(d bool) {
+	m.mu.Lock()
+	m.debug = d
+	m.mu.Unlock()
+
 	m.hooks.Range(func(_, v any) bool {
 		v.(*LogHook).Debug = d
 		return true
@@ -177,7 +374,7 @@ func (m *Redis) SetDebug(d bool) {
 
 func (m *Redis) Close() {
 	m.list.Range(func(k, v any) bool {
-		if err := v.(*redis.Client).Close(); err != nil {
+		if err := v.(*Client).Close(); err != nil {
 			logger.M().Error("关闭 Redis 资源异常", slog.String("name", k.(string)), slog.String("error", err.Error()))
 		}
 
@@ -0,0 +1,236 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/yrbb/rain/pkg/logger"
+	"github.com/yrbb/rain/pkg/logger/writer"
+)
+
+// logs 记录 error 级别的日志, 用于检查 LogHook 的输出
+var logs = &memWriter{}
+
+type memWriter struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (w *memWriter) Write(_ slog.Level, data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lines = append(w.lines, string(data))
+
+	return nil
+}
+
+func (w *memWriter) Close() {}
+
+func TestMain(m *testing.M) {
+	logger.Init("test", "error",
+		logger.Sink{Writer: writer.NewFileWriter("test", os.TempDir(), writer.Options{SplitTime: 60})},
+		logger.Sink{Writer: logs},
+	)
+
+	code := m.Run()
+	logger.Close()
+
+	os.Exit(code)
+}
+
+func TestStandalone(t *testing.T) {
+	s := miniredis.RunT(t)
+
+	m, err := New([]Config{{Name: "default", Addr: "redis://" + s.Addr()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	m.SetDebug(true)
+
+	rdb, err := m.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = rdb.Set(context.Background(), "k", "v", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := s.Get("k"); v != "v" {
+		t.Fatalf("unexpected value %q", v)
+	}
+
+	if _, err = m.Get("unknown"); err == nil {
+		t.Fatal("expected error for unknown name")
+	}
+}
+
+func TestCluster(t *testing.T) {
+	s := miniredis.RunT(t)
+
+	m, err := New([]Config{{Name: "cluster", Mode: ModeCluster, Addrs: []string{s.Addr()}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	rdb, err := m.Get("cluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = rdb.Set(context.Background(), "k", "v", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := rdb.Get(context.Background(), "k").Result(); v != "v" {
+		t.Fatalf("unexpected value %q", v)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []Config{
+		{Name: "a", Mode: ModeCluster},
+		{Name: "b", Mode: ModeFailover, Addrs: []string{"127.0.0.1:26379"}},
+		{Name: "c", Mode: "unknown"},
+	}
+
+	for _, c := range cases {
+		if err := c.validate(); err == nil {
+			t.Fatalf("%s: expected validate error", c.Name)
+		}
+	}
+}
+
+func TestLogArgs(t *testing.T) {
+	ctx := context.Background()
+
+	auth := redis.NewStatusCmd(ctx, "auth", "user", "pass")
+	if args := logArgs(auth); args[1] != "*" || args[2] != "*" {
+		t.Fatalf("auth args not masked: %v", args)
+	}
+
+	hello := redis.NewMapStringInterfaceCmd(ctx, "hello", 3, "auth", "user", "pass", "setname", "x")
+	if args := logArgs(hello); args[3] != "*" || args[4] != "*" || args[6] != "x" {
+		t.Fatalf("hello args not masked: %v", args)
+	}
+
+	set := redis.NewStatusCmd(ctx, "set", "k", strings.Repeat("a", 1000))
+	if args := logArgs(set); len(args[2].(string)) > maxLogArgLen+32 {
+		t.Fatalf("value not truncated: %v", args)
+	}
+}
+
+func TestPipelineLog(t *testing.T) {
+	s := miniredis.RunT(t)
+
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer rdb.Close()
+
+	rdb.AddHook(&LogHook{Name: "pipeline"})
+
+	_, err := rdb.Pipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.Set(context.Background(), "k", "v", 0)
+		pipe.Incr(context.Background(), "k")
+
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected incr error")
+	}
+
+	logs.mu.Lock()
+	defer logs.mu.Unlock()
+
+	var line string
+	for _, l := range logs.lines {
+		if strings.Contains(l, `"name":"pipeline"`) {
+			line = l
+		}
+	}
+
+	var entry struct {
+		Message string `json:"message"`
+		Fields  struct {
+			Count  int               `json:"count"`
+			Cmds   []string          `json:"cmds"`
+			Errors map[string]string `json:"errors"`
+			Error  string            `json:"error"`
+		} `json:"fields"`
+	}
+
+	if err = json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("unexpected log %q: %s", line, err)
+	}
+
+	if f := entry.Fields; entry.Message != "redis-pipeline" || f.Count != 2 ||
+		strings.Join(f.Cmds, ",") != "set k v,incr k" || f.Errors["1"] == "" || f.Error == "" {
+		t.Fatalf("unexpected log %q", line)
+	}
+}
+
+func TestUpdateConfig(t *testing.T) {
+	ctx := context.Background()
+	s1, s2 := miniredis.RunT(t), miniredis.RunT(t)
+
+	m, err := New([]Config{{Name: "default", Addr: "redis://" + s1.Addr()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	rdb, _ := m.Get()
+	_ = rdb.Set(ctx, "k", "1", 0).Err()
+
+	// 配置未变化时不重建
+	before := rdb.(*Client).Unwrap()
+	m.UpdateConfig([]Config{{Name: "default", Addr: "redis://" + s1.Addr()}})
+	if rdb.(*Client).Unwrap() != before {
+		t.Fatal("client rebuilt without config change")
+	}
+
+	ok := m.UpdateConfig([]Config{
+		{Name: "default", Mode: ModeCluster, Addrs: []string{s2.Addr()}},
+		{Name: "extra", Addr: "redis://" + s1.Addr()},
+	})
+	if !ok {
+		t.Fatal("update failed")
+	}
+
+	// 持有的句柄切换到新的实例
+	if err = rdb.Set(ctx, "k", "2", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := s2.Get("k"); v != "2" {
+		t.Fatalf("unexpected value %q", v)
+	}
+
+	if _, err = m.Get("extra"); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok = m.UpdateConfig([]Config{{Name: "default", Addr: "redis://127.0.0.1:1"}}); ok {
+		t.Fatal("expected failure for unreachable addr")
+	}
+
+	// 连接失败时保留旧客户端, 未出现在新配置中的实例被移除
+	if err = rdb.Get(ctx, "k").Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = m.Get("extra"); err == nil {
+		t.Fatal("extra should be removed")
+	}
+}
@@ -0,0 +1,284 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/yrbb/rain/pkg/lock"
+)
+
+const (
+	OverlapAllow = "allow" // 上次未结束时仍然执行
+	OverlapSkip  = "skip"  // 上次未结束时跳过本次
+	OverlapQueue = "queue" // 上次未结束时等待其结束后执行
+)
+
+// lockHold 单实例任务持有锁的最短时间, 避免各实例时钟偏差导致同一时刻的任务重复执行
+const lockHold = time.Second
+
+// 支持 5 位 (分 时 日 月 周) 及 6 位 (秒 分 时 日 月 周) 表达式, 以及 @every 1m, @daily 等
+var parser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+type Job func(ctx context.Context) error
+
+type Option func(e *entry)
+
+// Overlap 设置上次执行未结束时的处理方式, 默认 OverlapSkip
+func Overlap(policy string) Option {
+	return func(e *entry) {
+		e.overlap = policy
+	}
+}
+
+// Timezone 设置表达式使用的时区, 如 Asia/Shanghai, 默认使用 Options.Location
+func Timezone(tz string) Option {
+	return func(e *entry) {
+		e.timezone = tz
+	}
+}
+
+// SingleInstance 多个实例中同一时间只有一个执行, 使用 redisName 对应的 Redis 加锁 (默认 default)
+func SingleInstance(redisName ...string) Option {
+	return func(e *entry) {
+		e.single = true
+		e.redis = "default"
+
+		if len(redisName) > 0 {
+			e.redis = redisName[0]
+		}
+	}
+}
+
+type Options struct {
+	Location *time.Location                           // 默认 time.Local
+	Locker   func(redis string) (*lock.Locker, error) // 单实例执行时获取分布式锁
+}
+
+type entry struct {
+	s        *Scheduler
+	name     string
+	spec     string
+	job      Job
+	overlap  string
+	timezone string
+	single   bool
+	redis    string
+	sem      chan struct{}
+}
+
+type Scheduler struct {
+	opts    Options
+	cron    *cron.Cron
+	entries map[string]*entry
+
+	stopping context.Context // Stop 开始后取消, 不再执行等待中的任务
+	stop     context.CancelFunc
+	jobCtx   context.Context // Stop 超时后取消执行中任务的 ctx
+	abort    context.CancelFunc
+}
+
+func New(opts Options) *Scheduler {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	s := &Scheduler{
+		opts:    opts,
+		cron:    cron.New(cron.WithParser(parser), cron.WithLocation(opts.Location), cron.WithLogger(cron.DiscardLogger)),
+		entries: map[string]*entry{},
+	}
+
+	s.stopping, s.stop = context.WithCancel(context.Background())
+	s.jobCtx, s.abort = context.WithCancel(context.Background())
+
+	return s
+}
+
+func (s *Scheduler) Add(spec, name string, job Job, opts ...Option) error {
+	if _, ok := s.entries[name]; ok {
+		return fmt.Errorf("定时任务 %s 已存在", name)
+	}
+
+	e := &entry{
+		s:       s,
+		name:    name,
+		spec:    spec,
+		job:     job,
+		overlap: OverlapSkip,
+		sem:     make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	switch e.overlap {
+	case OverlapAllow, OverlapSkip, OverlapQueue:
+	default:
+		return fmt.Errorf("定时任务 %s 不支持的 overlap: %s", name, e.overlap)
+	}
+
+	if e.single && s.opts.Locker == nil {
+		return fmt.Errorf("定时任务 %s 未配置分布式锁", name)
+	}
+
+	if e.timezone != "" {
+		if _, err := time.LoadLocation(e.timezone); err != nil {
+			return fmt.Errorf("定时任务 %s 时区异常: %w", name, err)
+		}
+
+		spec = "CRON_TZ=" + e.timezone + " " + spec
+	}
+
+	if _, err := s.cron.AddJob(spec, e); err != nil {
+		return fmt.Errorf("定时任务 %s 表达式异常: %w", name, err)
+	}
+
+	s.entries[name] = e
+
+	return nil
+}
+
+// Len 返回定时任务数
+func (s *Scheduler) Len() int {
+	return len(s.entries)
+}
+
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop 停止调度并等待执行中的任务结束, ctx 结束时取消执行中任务的 ctx 并返回 ctx.Err()
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.stop()
+	done := s.cron.Stop()
+
+	defer s.abort()
+
+	select {
+	case <-done.Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Next 返回定时任务的下次执行时间
+func (s *Scheduler) Next(name string) (time.Time, bool) {
+	e, ok := s.entries[name]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, v := range s.cron.Entries() {
+		if v.Job == e {
+			return v.Next, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+var _ cron.Job = (*entry)(nil)
+
+func (e *entry) Run() {
+	switch e.overlap {
+	case OverlapSkip:
+		select {
+		case e.sem <- struct{}{}:
+		default:
+			slog.Warn("schedule-skip", slog.String("name", e.name), slog.String("reason", "running"))
+			return
+		}
+	case OverlapQueue:
+		select {
+		case e.sem <- struct{}{}:
+		case <-e.s.stopping.Done():
+			return
+		}
+	}
+
+	if e.overlap != OverlapAllow {
+		defer func() { <-e.sem }()
+	}
+
+	if e.s.stopping.Err() != nil {
+		return
+	}
+
+	ctx := e.s.jobCtx
+	start := time.Now()
+
+	if e.single {
+		l, err := e.lock()
+		if err != nil {
+			if errors.Is(err, lock.ErrNotObtained) {
+				slog.Debug("schedule-skip", slog.String("name", e.name), slog.String("reason", "locked"))
+			} else {
+				slog.Error("schedule-lock", slog.String("name", e.name), slog.String("error", err.Error()))
+			}
+
+			return
+		}
+
+		defer func() {
+			if err := l.ReleaseAfter(context.Background(), lockHold-time.Since(start)); err != nil {
+				slog.Error("schedule-unlock", slog.String("name", e.name), slog.String("error", err.Error()))
+			}
+		}()
+
+		// 租约丢失时取消任务的 ctx
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+
+		stop := context.AfterFunc(l.Context(), cancel)
+		defer stop()
+	}
+
+	err := e.run(ctx)
+
+	fields := []any{
+		slog.String("name", e.name),
+		slog.String("spec", e.spec),
+		slog.Int("took", int(time.Since(start).Milliseconds())),
+	}
+
+	if err != nil {
+		slog.Error("schedule", append(fields, slog.String("error", err.Error()))...)
+		return
+	}
+
+	slog.Info("schedule", fields...)
+}
+
+func (e *entry) lock() (*lock.Lock, error) {
+	locker, err := e.s.opts.Locker(e.redis)
+	if err != nil {
+		return nil, err
+	}
+
+	return locker.TryLock(context.Background(), e.name)
+}
+
+func (e *entry) run(ctx context.Context) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			var buf [4096]byte
+			n := runtime.Stack(buf[:], false)
+			slog.Error("任务异常", slog.Any("error", p), slog.String("stack", string(buf[:n])))
+
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return e.job(ctx)
+}
@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdd(t *testing.T) {
+	s := New(Options{})
+	job := func(ctx context.Context) error { return nil }
+
+	if err := s.Add("*/5 * * * *", "five", job); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Add("0 */5 * * * *", "six", job, Timezone("Asia/Shanghai")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Add("* * *", "invalid", job); err == nil {
+		t.Fatal("expected error for invalid spec")
+	}
+
+	if err := s.Add("* * * * *", "five", job); err == nil {
+		t.Fatal("expected error for duplicate name")
+	}
+
+	if err := s.Add("* * * * *", "tz", job, Timezone("Invalid/Zone")); err == nil {
+		t.Fatal("expected error for invalid timezone")
+	}
+
+	if err := s.Add("* * * * *", "single", job, SingleInstance()); err == nil {
+		t.Fatal("expected error without locker")
+	}
+}
+
+func TestOverlapSkip(t *testing.T) {
+	s := New(Options{})
+
+	var runs atomic.Int64
+
+	_ = s.Add("* * * * * *", "slow", func(ctx context.Context) error {
+		runs.Add(1)
+		time.Sleep(3 * time.Second)
+
+		return nil
+	})
+
+	s.Start()
+	time.Sleep(2500 * time.Millisecond)
+
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := runs.Load(); n != 1 {
+		t.Fatalf("expected 1 run, got %d", n)
+	}
+}
@@ -0,0 +1,94 @@
+package rain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yrbb/rain/pkg/logger"
+	"github.com/yrbb/rain/pkg/queue"
+)
+
+func registerQueueCommand(p *Rain) {
+	cmd := &cobra.Command{
+		Use:   "queue:work",
+		Short: "consume queue jobs",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			q, err := Queue()
+			if err != nil {
+				return err
+			}
+
+			for name, handler := range p.jobHandlers {
+				if err = q.Handle(name, handler); err != nil {
+					return err
+				}
+			}
+
+			queues, _ := cmd.Flags().GetStringSlice("queues")
+			if err = q.Start(context.Background(), queues...); err != nil {
+				return err
+			}
+
+			if len(queues) == 0 {
+				queues = q.Queues()
+			}
+
+			logger.M().Info(fmt.Sprintf("开始消费队列: %s", strings.Join(queues, ", ")))
+
+			p.OnStop(func() {
+				ctx, cancel := context.WithTimeout(context.Background(), p.Config().Queue.StopTimeout*time.Second)
+				defer cancel()
+
+				if err := q.Stop(ctx); err != nil {
+					logger.M().Error("队列停止超时", slog.String("error", err.Error()))
+				} else {
+					logger.M().Info("队列停止")
+				}
+			})
+
+			// 单实例运行时锁丢失会取消 Context
+			<-cmd.Context().Done()
+
+			return cmd.Context().Err()
+		},
+	}
+
+	cmd.Flags().StringSlice("queues", nil, "queues to consume, default all registered")
+
+	p.cmd.AddCommand(cmd)
+}
+
+// HandleJob 注册队列任务处理函数, 由 queue:work 命令消费
+func (p *Rain) HandleJob(name string, handler queue.Handler) {
+	if p.jobHandlers == nil {
+		p.jobHandlers = map[string]queue.Handler{}
+	}
+
+	p.jobHandlers[name] = handler
+}
+
+func (p *Rain) initQueue() error {
+	if p.redis == nil {
+		return nil
+	}
+
+	client, err := p.redis.Get(p.Config().Queue.Redis)
+	if err != nil {
+		// 未配置队列使用的 Redis 时不启用队列
+		if len(p.jobHandlers) == 0 {
+			return nil
+		}
+
+		return errors.New("队列 Redis 资源不存在: " + p.Config().Queue.Redis)
+	}
+
+	p.queue = queue.New(client, p.Config().Queue.options())
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -17,9 +18,12 @@ import (
 	"github.com/panjf2000/ants/v2"
 	"github.com/spf13/cobra"
 
+	"github.com/yrbb/rain/pkg/auth"
 	"github.com/yrbb/rain/pkg/database"
 	"github.com/yrbb/rain/pkg/logger"
+	"github.com/yrbb/rain/pkg/queue"
 	"github.com/yrbb/rain/pkg/redis"
+	"github.com/yrbb/rain/pkg/schedule"
 )
 
 func init() {
@@ -27,26 +31,37 @@ func init() {
 }
 
 type Rain struct {
-	pid        int
-	started    int32
-	closed     int32
-	exitCh     chan os.Signal
-	isShowHelp bool
-	isServer   bool
-
-	config *Config
-
-	cmd      *cobra.Command
-	database *database.Database
-	redis    *redis.Redis
-	worker   *ants.Pool
-	engine   *gin.Engine
-	server   *http.Server
-	watcher  *fsnotify.Watcher
+	pid         int
+	started     int32
+	closed      int32
+	exitCh      chan os.Signal
+	isShowHelp  bool
+	isServer    bool
+	runSchedule bool
+
+	config     atomic.Pointer[Config]
+	configFile string
+	profile    string
+
+	cmd       *cobra.Command
+	database  *database.Database
+	redis     *redis.Redis
+	auth      *auth.Authenticator
+	queue     *queue.Queue
+	scheduler *schedule.Scheduler
+	worker    *ants.Pool
+	engine    *gin.Engine
+	server    *http.Server
+	watcher   *fsnotify.Watcher
 
 	beforeStart    []func()
 	beforeStop     []func()
 	onConfigUpdate func(config *Config)
+	rateLimitKey   func(c *gin.Context) string
+	jobHandlers    map[string]queue.Handler
+	configBinds    []configBind
+	configChanges  []configChange
+	reloadMu       sync.Mutex
 }
 
 func New() (*Rain, error) {
@@ -62,6 +77,8 @@ func New() (*Rain, error) {
 
 	registerServerCommand(p)
 	registerVersionCommand(p)
+	registerQueueCommand(p)
+	registerScheduleCommand(p)
 
 	cmd, args, err := p.cmd.Find(os.Args[1:])
 	if err != nil {
@@ -82,12 +99,24 @@ func New() (*Rain, error) {
 			file = "config.toml"
 		}
 
-		if p.config, err = parseConfig(file); err != nil {
+		profile, _ := cmd.Flags().GetString("profile")
+		if profile == "" {
+			profile = os.Getenv(envProfile)
+		}
+
+		p.configFile, p.profile = file, profile
+
+		cfg, err := parseConfig(file, profile)
+		if err != nil {
 			return nil, err
 		}
 
-		p.initLogger()
-		p.initConfigWatcher(file, p.configUpdate)
+		p.config.Store(cfg)
+
+		if err = p.initLogger(); err != nil {
+			return nil, err
+		}
+		p.initConfigWatcher(p.reloadConfig)
 	}
 
 	gin.SetMode(gin.ReleaseMode)
@@ -98,17 +127,24 @@ func New() (*Rain, error) {
 	return p, nil
 }
 
-func (p *Rain) initLogger() {
-	logger.Init(
-		p.config.Project,
-		p.config.Logger.Level,
-		p.config.Logger.Path,
-		p.config.Logger.SplitTime,
-	)
-	logger.SetDebug(p.config.Debug)
+func (p *Rain) initLogger() error {
+	sinks, err := p.Config().Logger.sinks(p.Config().Project)
+	if err != nil {
+		return err
+	}
+
+	logger.SetRedactor(p.Config().Logger.Redact.redactor())
+	logger.Init(p.Config().Project, p.Config().Logger.Level, sinks...)
+	logger.SetDebug(p.Config().Debug)
+
+	for name, lvl := range p.Config().Logger.Levels {
+		_ = logger.SetNamedLevel(name, lvl)
+	}
+
+	return nil
 }
 
-func (p *Rain) initConfigWatcher(file string, callback func(*Config)) {
+func (p *Rain) initConfigWatcher(callback func(*Config)) {
 	var err error
 	p.watcher, err = fsnotify.NewWatcher()
 	if err != nil {
@@ -116,7 +152,11 @@ func (p *Rain) initConfigWatcher(file string, callback func(*Config)) {
 		os.Exit(1)
 	}
 
-	watchConfig(p.watcher, file, callback)
+	load := func() (*Config, error) {
+		return parseConfig(p.configFile, p.profile)
+	}
+
+	watchConfig(p.watcher, configFiles(p.configFile, p.profile), load, callback)
 }
 
 func (p *Rain) beforeStartCallback() {
@@ -136,6 +176,8 @@ func (p *Rain) beforeStartCallback() {
 			p.beforeStart[i]()
 		}
 	}
+
+	p.startScheduler()
 }
 
 func (p *Rain) serverReadyCheck() {
@@ -155,7 +197,7 @@ func (p *Rain) serverReadyCheck() {
 
 		time.Sleep(time.Millisecond * 10)
 
-		if serverIsReady(p.config.Server.Listen) {
+		if serverIsReady(p.Config().Server.Listen) {
 			logger.M().Info("HTTP 服务自检通过")
 			break
 		}
@@ -173,34 +215,6 @@ func (p *Rain) Run() {
 	}
 }
 
-func (p *Rain) configUpdate(cfg *Config) {
-	logger.SetDebug(cfg.Debug)
-
-	if p.redis != nil {
-		p.redis.UpdateConfig(cfg.Redis)
-	} else {
-		p.redis, _ = redis.New(cfg.Redis)
-	}
-
-	if p.redis != nil {
-		p.redis.SetDebug(cfg.Debug)
-	}
-
-	if p.database != nil {
-		p.database.UpdateConfig(cfg.Database)
-	} else {
-		p.database, _ = database.New(cfg.Database)
-	}
-
-	if p.database != nil {
-		p.database.SetDebug(cfg.Debug)
-	}
-
-	if p.onConfigUpdate != nil {
-		p.onConfigUpdate(cfg)
-	}
-}
-
 func (p *Rain) listenSignals() {
 	signal.Notify(p.exitCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR1)
 
@@ -214,7 +228,7 @@ func (p *Rain) listenSignals() {
 		logger.M().Info(fmt.Sprintf("收到信号: %s, Pid: %d", sig.String(), p.pid))
 
 		if p.server != nil {
-			ctx, cancel := context.WithTimeout(context.Background(), p.config.Server.StopTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), p.Config().Server.StopTimeout*time.Second)
 			if err := p.server.Shutdown(ctx); err != nil {
 				logger.M().Error("HTTP 服务停止异常", slog.String("error", err.Error()))
 			} else {
@@ -265,30 +279,39 @@ func (p *Rain) checkIsHelpCommand(cmd *cobra.Command, args []string) {
 }
 
 func (p *Rain) initComponents() error {
+	if err := p.bindConfig(p.Config()); err != nil {
+		return err
+	}
+
 	err := p.initWorker()
 	if err != nil {
 		return err
 	}
 
-	p.redis, err = redis.New(p.config.Redis)
+	p.redis, err = redis.New(p.Config().Redis)
 	if err != nil {
 		return err
 	}
 
 	if p.redis != nil {
-		p.redis.SetDebug(p.config.Debug)
+		p.redis.SetDebug(p.Config().Debug)
 	}
 
-	p.database, err = database.New(p.config.Database)
+	p.database, err = database.New(p.Config().Database)
 	if err != nil {
 		return err
 	}
 
 	if p.database != nil {
-		p.database.SetDebug(p.config.Debug)
+		p.database.SetDebug(p.Config().Debug)
 	}
 
-	return nil
+	p.auth, err = auth.New(&p.Config().Auth)
+	if err != nil {
+		return err
+	}
+
+	return p.initQueue()
 }
 
 func (p *Rain) cleanComponents() {
@@ -315,11 +338,7 @@ func (p *Rain) cleanComponents() {
 func (p *Rain) initWorker() (err error) {
 	ants.Release()
 
-	if p.config.Worker.Capacity == 0 {
-		p.config.Worker.Capacity = 1000
-	}
-
-	p.worker, err = ants.NewPool(p.config.Worker.Capacity, p.config.Worker.Options()...)
+	p.worker, err = ants.NewPool(p.Config().Worker.Capacity, p.Config().Worker.Options()...)
 	if err != nil {
 		return
 	}
@@ -337,10 +356,19 @@ func (p *Rain) OnStop(callback func()) {
 	p.beforeStop = append(p.beforeStop, callback)
 }
 
+// Config 返回当前生效的配置, 热更新后返回新的配置
+func (p *Rain) Config() *Config {
+	return p.config.Load()
+}
+
 func (p *Rain) OnConfigUpdate(callback func(config *Config)) {
 	p.onConfigUpdate = callback
 }
 
+func (p *Rain) SetRateLimitKeyFunc(fn func(c *gin.Context) string) {
+	p.rateLimitKey = fn
+}
+
 func (p *Rain) Router() *gin.Engine {
 	return p.engine
 }
@@ -0,0 +1,78 @@
+package rain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yrbb/rain/pkg/lock"
+	"github.com/yrbb/rain/pkg/logger"
+	"github.com/yrbb/rain/pkg/schedule"
+)
+
+func registerScheduleCommand(p *Rain) {
+	p.cmd.AddCommand(&cobra.Command{
+		Use:   "schedule:work",
+		Short: "run scheduled jobs",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			p.runSchedule = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// 单实例运行时锁丢失会取消 Context
+			<-cmd.Context().Done()
+
+			return cmd.Context().Err()
+		},
+	})
+}
+
+// Schedule 添加定时任务, spec 支持 5 位及 6 位 (含秒) cron 表达式,
+// 任务在 server 及 schedule:work 命令中执行, OnStart 回调之后开始调度
+func (p *Rain) Schedule(spec, name string, job schedule.Job, opts ...schedule.Option) error {
+	if p.Config() == nil {
+		return nil
+	}
+
+	if p.scheduler == nil {
+		p.scheduler = schedule.New(schedule.Options{
+			Location: p.Config().Schedule.location,
+			Locker:   p.scheduleLocker,
+		})
+	}
+
+	return p.scheduler.Add(spec, name, job, opts...)
+}
+
+func (p *Rain) scheduleLocker(redisName string) (*lock.Locker, error) {
+	client, err := Redis(redisName)
+	if err != nil {
+		return nil, err
+	}
+
+	return lock.New(client, lock.Options{Prefix: "rain:lock:" + p.Config().Project + ":schedule:"}), nil
+}
+
+func (p *Rain) startScheduler() {
+	if !p.runSchedule || p.scheduler == nil || p.scheduler.Len() == 0 {
+		return
+	}
+
+	p.scheduler.Start()
+
+	logger.M().Info(fmt.Sprintf("启动定时任务, 共 %d 个", p.scheduler.Len()))
+
+	p.OnStop(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), p.Config().Schedule.StopTimeout*time.Second)
+		defer cancel()
+
+		if err := p.scheduler.Stop(ctx); err != nil {
+			logger.M().Error("定时任务停止超时", slog.String("error", err.Error()))
+		} else {
+			logger.M().Info("定时任务停止")
+		}
+	})
+}
@@ -2,8 +2,11 @@ package rain
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
@@ -19,18 +22,46 @@ func registerServerCommand(p *Rain) {
 		Short: "http server",
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			p.isServer = true
+			p.runSchedule = true
 
-			if err := p.config.Server.validate(); err != nil {
+			if err := p.Config().Server.validate(); err != nil {
 				return err
 			}
 
 			p.engine.Use(
-				middleware.Logger(),
+				middleware.LoggerWithOptions(p.Config().Server.AccessLog.options()),
 				middleware.Recovery(),
 			)
 
-			if p.config.Debug || p.config.Server.EnablePProf {
+			if p.Config().Server.CORS.Enable {
+				p.engine.Use(middleware.CORS(p.Config().Server.CORS.options()))
+			}
+
+			if p.Config().Server.Security.Enable {
+				p.engine.Use(middleware.Secure(p.Config().Server.Security.options()))
+			}
+
+			if p.Config().Server.MaxBodySize > 0 {
+				p.engine.Use(middleware.BodyLimit(p.Config().Server.MaxBodySize))
+			}
+
+			if p.Config().Server.Compress.Enable {
+				p.engine.Use(middleware.Compress(p.Config().Server.Compress.options()))
+			}
+
+			if p.Config().Server.RateLimit.Enable {
+				p.engine.Use(p.rateLimit())
+			}
+
+			if p.Config().Server.Idempotency.Enable {
+				p.engine.Use(p.idempotency())
+			}
+
+			if p.Config().Debug || p.Config().Server.EnablePProf {
 				pprof.Register(p.engine)
+
+				p.engine.GET("/debug/log/levels", getLogLevels)
+				p.engine.PUT("/debug/log/levels", putLogLevels)
 			}
 
 			p.engine.GET("/health", func(c *gin.Context) {
@@ -38,22 +69,22 @@ func registerServerCommand(p *Rain) {
 			})
 
 			p.server = &http.Server{
-				Addr:    p.config.Server.Listen,
+				Addr:    p.Config().Server.Listen,
 				Handler: p.engine,
 			}
 
-			if p.config.Server.ReadTimeout > 0 {
-				p.server.ReadTimeout = p.config.Server.ReadTimeout
+			if p.Config().Server.ReadTimeout > 0 {
+				p.server.ReadTimeout = p.Config().Server.ReadTimeout * time.Second
 			}
 
-			if p.config.Server.WriteTimeout > 0 {
-				p.server.WriteTimeout = p.config.Server.WriteTimeout
+			if p.Config().Server.WriteTimeout > 0 {
+				p.server.WriteTimeout = p.Config().Server.WriteTimeout * time.Second
 			}
 
 			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
-			logger.M().Info(fmt.Sprintf("HTTP 服务启动, 监听: %s", p.config.Server.Listen))
+			logger.M().Info(fmt.Sprintf("HTTP 服务启动, 监听: %s", p.Config().Server.Listen))
 
 			if err := p.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				return err
@@ -64,6 +95,113 @@ func registerServerCommand(p *Rain) {
 	})
 }
 
+func (p *Rain) rateLimit() gin.HandlerFunc {
+	cfg := p.Config().Server.RateLimit
+	opts := cfg.options()
+
+	if p.rateLimitKey != nil {
+		opts.KeyFunc = p.rateLimitKey
+	}
+
+	if cfg.Store != "redis" {
+		return middleware.RateLimit(opts)
+	}
+
+	// Redis 在命令执行前才会初始化, 首次请求时再创建存储
+	var (
+		once    sync.Once
+		handler gin.HandlerFunc
+	)
+
+	return func(c *gin.Context) {
+		once.Do(func() {
+			if client, err := Redis(cfg.Redis); err == nil {
+				opts.Store = middleware.NewRedisRateLimitStore(client)
+			} else {
+				logger.M().Error("限流 Redis 资源不存在, 使用本地存储", slog.String("name", cfg.Redis))
+			}
+
+			handler = middleware.RateLimit(opts)
+		})
+
+		handler(c)
+	}
+}
+
+func (p *Rain) idempotency() gin.HandlerFunc {
+	cfg := p.Config().Server.Idempotency
+	opts := cfg.options()
+
+	if p.Config().Server.MaxBodySize > 0 {
+		opts.MaxBodySize = p.Config().Server.MaxBodySize
+	}
+
+	// Redis 在命令执行前才会初始化, 首次请求时再获取
+	var (
+		once    sync.Once
+		handler gin.HandlerFunc
+	)
+
+	return func(c *gin.Context) {
+		once.Do(func() {
+			client, err := Redis(cfg.Redis)
+			if err != nil {
+				logger.M().Error("幂等 Redis 资源不存在, 不启用幂等处理", slog.String("name", cfg.Redis))
+			}
+
+			opts.Client = client
+			handler = middleware.Idempotency(opts)
+		})
+
+		handler(c)
+	}
+}
+
+type logLevels struct {
+	Level   string            `json:"level"`   // 全局级别
+	Loggers map[string]string `json:"loggers"` // 具名日志的级别, 为空时使用全局级别
+}
+
+func getLogLevels(c *gin.Context) {
+	c.JSON(http.StatusOK, logLevels{
+		Level:   logger.LevelString(logger.GetLevel()),
+		Loggers: logger.NamedLevels(),
+	})
+}
+
+// putLogLevels 运行时调整日志级别, 只修改请求中包含的项, 具名日志的级别为空时恢复使用全局级别
+func putLogLevels(c *gin.Context) {
+	var req logLevels
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := logger.ParseLevel(req.Level); req.Level != "" && !ok {
+		middleware.AbortWithStatus(c, http.StatusBadRequest, "invalid level: "+req.Level)
+		return
+	}
+
+	for name, lvl := range req.Loggers {
+		if _, ok := logger.ParseLevel(lvl); lvl != "" && !ok {
+			middleware.AbortWithStatus(c, http.StatusBadRequest, "invalid level of "+name+": "+lvl)
+			return
+		}
+	}
+
+	if req.Level != "" {
+		logger.SetLevel(req.Level)
+	}
+
+	for name, lvl := range req.Loggers {
+		_ = logger.SetNamedLevel(name, lvl)
+	}
+
+	slog.Warn("log-level", slog.String("level", req.Level), slog.Any("loggers", req.Loggers), slog.String("ip", c.ClientIP()))
+
+	getLogLevels(c)
+}
+
 func serverIsReady(listen string) bool {
 	if strings.HasPrefix(listen, "0.0.0.0") {
 		listen = strings.Replace(listen, "0.0.0.0", "127.0.0.1", 1)